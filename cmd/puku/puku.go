@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,10 +10,12 @@ import (
 	clilogging "github.com/peterebden/go-cli-init/v5/logging"
 
 	"github.com/please-build/puku/config"
+	"github.com/please-build/puku/daemon"
 	"github.com/please-build/puku/generate"
 	"github.com/please-build/puku/graph"
 	"github.com/please-build/puku/licences"
 	"github.com/please-build/puku/logging"
+	"github.com/please-build/puku/lsp"
 	"github.com/please-build/puku/migrate"
 	"github.com/please-build/puku/options"
 	"github.com/please-build/puku/please"
@@ -45,8 +48,23 @@ var opts = struct {
 			Paths []string `positional-arg-name:"packages" description:"The packages to process"`
 		} `positional-args:"true"`
 	} `command:"lint" description:"Lint build files in the provided paths"`
-	Watch struct {
+	Graph struct {
+		Format string `short:"f" long:"format" choice:"dot" choice:"json" default:"dot" description:"output format for the graph"` //nolint
+		Args   struct {
+			Paths []string `positional-arg-name:"packages" description:"The packages to process"`
+		} `positional-args:"true"`
+	} `command:"graph" description:"Print the dependency graph of the Go targets in the provided paths"`
+	Why struct {
 		Args struct {
+			Import  string `positional-arg-name:"import" required:"true" description:"The import path to resolve"`
+			Package string `positional-arg-name:"package" description:"The package to resolve the import from. Defaults to the current directory."`
+		} `positional-args:"true"`
+	} `command:"why" description:"Print the target an import resolves to"`
+	Daemon struct{} `command:"daemon" description:"Serve fmt, lint and why requests from other puku commands over a unix socket"`
+	Lsp    struct{} `command:"lsp" description:"Run a language server over stdin and stdout that reports missing deps as diagnostics"`
+	Watch  struct {
+		StatusFile string `long:"status_file" description:"File to write the status of watch mode to as JSON, for editor integrations"`
+		Args       struct {
 			Paths []string `positional-arg-name:"packages" description:"The packages to process"`
 		} `positional-args:"true"`
 	} `command:"watch" description:"Watch build files in the provided paths and update them when needed"`
@@ -100,6 +118,36 @@ var funcs = map[string]func(conf *config.Config, plzConf *please.Config, orignal
 	"lint": func(_ *config.Config, plzConf *please.Config, orignalWD string) int {
 		paths := work.MustExpandPaths(orignalWD, opts.Lint.Args.Paths)
 		if err := generate.UpdateToStdout(opts.Lint.Format, plzConf, opts.Options, paths...); err != nil {
+			if errors.Is(err, generate.ErrIssuesFound) {
+				return 1
+			}
+			log.Fatalf("%v", err)
+		}
+		return 0
+	},
+	"graph": func(_ *config.Config, plzConf *please.Config, orignalWD string) int {
+		paths := work.MustExpandPaths(orignalWD, opts.Graph.Args.Paths)
+		if err := generate.WriteGraph(os.Stdout, opts.Graph.Format, plzConf, opts.Options, paths...); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return 0
+	},
+	"why": func(_ *config.Config, plzConf *please.Config, orignalWD string) int {
+		resp := daemon.NewServer(plzConf, opts.Options).Handle(whyRequest(orignalWD))
+		return printResponse(resp)
+	},
+	"daemon": func(_ *config.Config, plzConf *please.Config, _ string) int {
+		if err := daemon.Serve(plzConf, opts.Options); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return 0
+	},
+	"lsp": func(_ *config.Config, plzConf *please.Config, _ string) int {
+		server, err := lsp.New(os.Stdin, os.Stdout, plzConf, opts.Options)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		if err := server.Serve(); err != nil {
 			log.Fatalf("%v", err)
 		}
 		return 0
@@ -110,7 +158,7 @@ var funcs = map[string]func(conf *config.Config, plzConf *please.Config, orignal
 			log.Fatalf("%v", err)
 		}
 
-		if err := watch.Watch(plzConf, opts.Options, paths...); err != nil {
+		if err := watch.Watch(plzConf, opts.Options, opts.Watch.StatusFile, paths...); err != nil {
 			log.Fatalf("%v", err)
 		}
 		return 0
@@ -181,9 +229,60 @@ func main() {
 		log.Fatalf("failed to read config: %v", err)
 	}
 
+	// Querying the config runs plz, so avoid doing that if the daemon can run the command for us
+	if code, ok := sendToDaemon(cmd, wd); ok {
+		os.Exit(code)
+	}
+
 	plzConf, err := please.QueryConfig(conf.GetPlzPath())
 	if err != nil {
 		log.Fatalf("failed to query config: %w", err)
 	}
 	os.Exit(funcs[cmd](conf, plzConf, wd))
 }
+
+// sendToDaemon runs the command on the puku daemon, if it's running and can run the command. Returns false if the
+// command should be run here instead.
+func sendToDaemon(cmd, wd string) (int, bool) {
+	var req *daemon.Request
+	switch cmd {
+	case "fmt":
+		req = &daemon.Request{Command: daemon.CommandUpdate, Paths: work.MustExpandPaths(wd, opts.Fmt.Args.Paths), Options: opts.Options}
+	case "lint":
+		req = &daemon.Request{Command: daemon.CommandLint, Paths: work.MustExpandPaths(wd, opts.Lint.Args.Paths), Format: opts.Lint.Format, Options: opts.Options}
+	case "why":
+		req = whyRequest(wd)
+	default:
+		return 0, false
+	}
+
+	resp, ok, err := daemon.Send(req)
+	if !ok {
+		return 0, false
+	}
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	// The daemon logged these on its own stderr, so pass them on to the user
+	for _, warning := range resp.Warnings {
+		log.Warningf("%v", warning)
+	}
+	return printResponse(resp), true
+}
+
+func whyRequest(wd string) *daemon.Request {
+	dir := wd
+	if opts.Why.Args.Package != "" {
+		dir = work.MustExpandPaths(wd, []string{opts.Why.Args.Package})[0]
+	}
+	return &daemon.Request{Command: daemon.CommandWhy, Import: opts.Why.Args.Import, Dir: dir, Options: opts.Options}
+}
+
+// printResponse prints the output of a command run by the daemon, returning the exit code
+func printResponse(resp *daemon.Response) int {
+	fmt.Print(resp.Output)
+	if resp.Error != "" {
+		log.Errorf("%v", resp.Error)
+	}
+	return resp.ExitCode
+}
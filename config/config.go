@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"text/template"
 
 	"github.com/please-build/puku/kinds"
 )
@@ -19,6 +21,15 @@ type KindConfig struct {
 	ProvidedDeps      []string `json:"providedDeps"`
 	DefaultVisibility []string `json:"defaultVisibility"`
 	SrcsArg           string   `json:"srcsArg"`
+	// EmbedArg is the argument that puku adds the files embedded with //go:embed to. Defaults to embed_srcs.
+	EmbedArg string `json:"embedArg"`
+	// DataArg is the argument that puku adds data files to, e.g. the testdata directory. Defaults to data.
+	DataArg string `json:"dataArg"`
+	// TestData controls whether puku adds the testdata directory to the data of test targets of this kind. Defaults
+	// to true.
+	TestData *bool `json:"testData"`
+	// ImportRules configures how blank and dot imports are handled for this kind, keyed by import path.
+	ImportRules map[string]*kinds.ImportRule `json:"importRules"`
 }
 
 func (kc *KindConfig) srcsArg() string {
@@ -28,27 +39,96 @@ func (kc *KindConfig) srcsArg() string {
 	return kc.SrcsArg
 }
 
+func (kc *KindConfig) dataArg() string {
+	if kc.DataArg == "" {
+		return "data"
+	}
+	return kc.DataArg
+}
+
+func (kc *KindConfig) embedArg() string {
+	if kc.EmbedArg == "" {
+		return "embed_srcs"
+	}
+	return kc.EmbedArg
+}
+
 // Config represents a puku.json file discovered in the repo. These are loaded for each directory, and form a chain of
 // configs all the way up to the root config. Configs at a deeper level in the file tree override values from configs at
 // a shallower level. The shallower config file is stored in (*Config).base` and the methods on this struct will recurse
 // into this base config where appropriate.
 type Config struct {
-	base                *Config
-	ThirdPartyDir       string                 `json:"thirdPartyDir"`
-	PleasePath          string                 `json:"pleasePath"`
-	KnownTargets        map[string]string      `json:"knownTargets"`
-	LibKinds            map[string]*KindConfig `json:"libKinds"`
-	TestKinds           map[string]*KindConfig `json:"testKinds"`
-	BinKinds            map[string]*KindConfig `json:"binKinds"`
-	Stop                *bool                  `json:"stop"`
-	EnsureSubincludes   *bool                  `json:"ensureSubincludes"`
-	ExcludeBuiltinKinds []string               `json:"excludeBuiltinKinds"`
-}
-
-// TODO we should reload this during plz watch so this probably needs to become a member of Update
+	base                    *Config
+	ThirdPartyDir           string                 `json:"thirdPartyDir"`
+	PleasePath              string                 `json:"pleasePath"`
+	KnownTargets            map[string]string      `json:"knownTargets"`
+	LibKinds                map[string]*KindConfig `json:"libKinds"`
+	TestKinds               map[string]*KindConfig `json:"testKinds"`
+	BinKinds                map[string]*KindConfig `json:"binKinds"`
+	BenchmarkKinds          map[string]*KindConfig `json:"benchmarkKinds"`
+	FuzzKinds               map[string]*KindConfig `json:"fuzzKinds"`
+	Stop                    *bool                  `json:"stop"`
+	EnsureSubincludes       *bool                  `json:"ensureSubincludes"`
+	ExcludeBuiltinKinds     []string               `json:"excludeBuiltinKinds"`
+	SplitBenchmarks         *bool                  `json:"splitBenchmarks"`
+	SplitFuzzTests          *bool                  `json:"splitFuzzTests"`
+	ErrorOnMultiplePackages *bool                  `json:"errorOnMultiplePackages"`
+	// QuerySources makes puku ask plz for the srcs of the targets in the package, rather than evaluating them itself
+	QuerySources  *bool             `json:"querySources"`
+	NameTemplates map[string]string `json:"nameTemplates"`
+	DefaultKinds  map[string]string `json:"defaultKinds"`
+	// NewRuleAttrs are the attributes set on new targets when puku creates them, keyed by kind then attribute name
+	NewRuleAttrs map[string]map[string]interface{} `json:"newRuleAttrs"`
+	// DependencyRules restrict what targets can depend on. These apply in addition to the rules from configs above.
+	DependencyRules []*DependencyRule `json:"dependencyRules"`
+}
+
+// builtinDefaultKinds are the kinds used for new targets when they aren't configured via defaultKinds
+var builtinDefaultKinds = map[kinds.Type]string{
+	kinds.Lib:       "go_library",
+	kinds.Test:      "go_test",
+	kinds.Bin:       "go_binary",
+	kinds.Benchmark: "go_benchmark",
+	kinds.Fuzz:      "go_fuzz_test",
+}
+
+// defaultNameTemplates are the templates used to name new targets when they aren't configured via nameTemplates
+var defaultNameTemplates = map[kinds.Type]string{
+	kinds.Lib:       "{{.Dir}}",
+	kinds.Test:      "{{.Dir}}_test",
+	kinds.Bin:       "main",
+	kinds.Benchmark: "{{.Dir}}_benchmark",
+	kinds.Fuzz:      "{{.Dir}}_fuzz_test",
+}
+
+// NameTemplateData is the data available to the templates used to name new targets
+type NameTemplateData struct {
+	// Dir is the name of the directory the target is in
+	Dir string
+	// Package is the name of the Go package the target is for
+	Package string
+}
+
 // configs contains a cache of configs for a given directory
 var configs = map[string]*Config{}
 
+// configsMux guards configs, as watch mode reads configs while puku is updating packages
+var configsMux sync.Mutex
+
+// InvalidateConfigs removes the configs for a directory and all the directories under it from the cache, so they're
+// read again the next time they're needed.
+func InvalidateConfigs(dir string) {
+	configsMux.Lock()
+	defer configsMux.Unlock()
+
+	dir = filepath.Clean(dir)
+	for path := range configs {
+		if dir == "." || path == dir || strings.HasPrefix(path, dir+"/") {
+			delete(configs, path)
+		}
+	}
+}
+
 // ReadConfig builds up the config for a given path
 func ReadConfig(dir string) (*Config, error) {
 	dir = filepath.Clean(dir)
@@ -88,6 +168,9 @@ func readConfigs(base *Config, path string, rest []string) (*Config, error) {
 
 // readOneConfig reads a config in a directory
 func readOneConfig(path string) (*Config, error) {
+	configsMux.Lock()
+	defer configsMux.Unlock()
+
 	if config, ok := configs[path]; ok {
 		return config, nil
 	}
@@ -157,6 +240,136 @@ func (c *Config) ShouldEnsureSubincludes() bool {
 	return true
 }
 
+// ShouldSplitBenchmarks returns whether test files that only contain benchmarks should be allocated to a separate
+// benchmark target, rather than the test target for the package.
+func (c *Config) ShouldSplitBenchmarks() bool {
+	if c.SplitBenchmarks != nil {
+		return *c.SplitBenchmarks
+	}
+	if c.base != nil {
+		return c.base.ShouldSplitBenchmarks()
+	}
+	return false
+}
+
+// ShouldSplitFuzzTests returns whether test files that contain fuzz tests should be allocated to a separate fuzz
+// target, rather than the test target for the package.
+func (c *Config) ShouldSplitFuzzTests() bool {
+	if c.SplitFuzzTests != nil {
+		return *c.SplitFuzzTests
+	}
+	if c.base != nil {
+		return c.base.ShouldSplitFuzzTests()
+	}
+	return false
+}
+
+// ShouldQuerySources returns whether puku should get the srcs of targets from plz, rather than evaluating them itself.
+// This handles any expression Please can, e.g. macros, at the cost of running plz for each package.
+func (c *Config) ShouldQuerySources() bool {
+	if c.QuerySources != nil {
+		return *c.QuerySources
+	}
+	if c.base != nil {
+		return c.base.ShouldQuerySources()
+	}
+	return false
+}
+
+// GetNameTemplate returns the template used to name new targets of the given type
+func (c *Config) GetNameTemplate(kindType kinds.Type) string {
+	if t, ok := c.NameTemplates[kindType.String()]; ok {
+		return t
+	}
+	if c.base != nil {
+		return c.base.GetNameTemplate(kindType)
+	}
+	return defaultNameTemplates[kindType]
+}
+
+// TargetName returns the name for a new target of the given type, for the Go package with the given name in the
+// directory
+func (c *Config) TargetName(kindType kinds.Type, dir, pkgName string) (string, error) {
+	tmpl, err := template.New(kindType.String()).Option("missingkey=error").Parse(c.GetNameTemplate(kindType))
+	if err != nil {
+		return "", fmt.Errorf("invalid name template for %v targets: %w", kindType, err)
+	}
+
+	name := new(strings.Builder)
+	data := NameTemplateData{
+		Dir:     filepath.Base(dir),
+		Package: pkgName,
+	}
+	if err := tmpl.Execute(name, data); err != nil {
+		return "", fmt.Errorf("invalid name template for %v targets: %w", kindType, err)
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("name template for %v targets produced an empty name", kindType)
+	}
+	return name.String(), nil
+}
+
+func (c *Config) getDefaultKindName(kindType kinds.Type) string {
+	if k, ok := c.DefaultKinds[kindType.String()]; ok {
+		return k
+	}
+	if c.base != nil {
+		return c.base.getDefaultKindName(kindType)
+	}
+	return ""
+}
+
+// GetDefaultKind returns the kind to use for new targets of the given type. This must be one of the kinds configured
+// for that type, or one of the built-in kinds.
+func (c *Config) GetDefaultKind(kindType kinds.Type) (*kinds.Kind, error) {
+	name := c.getDefaultKindName(kindType)
+	if name == "" {
+		return kinds.DefaultKinds[builtinDefaultKinds[kindType]], nil
+	}
+
+	kind := c.GetKind(name)
+	if kind == nil {
+		return nil, fmt.Errorf("unknown default kind for %v targets: %v", kindType, name)
+	}
+	if kind.Type != kindType {
+		return nil, fmt.Errorf("default kind for %v targets, %v, is a %v kind", kindType, name, kind.Type)
+	}
+	return kind, nil
+}
+
+// GetNewRuleAttrs returns the attributes to set on new targets of the given kind. Attributes from deeper configs
+// override the same attributes from shallower ones.
+func (c *Config) GetNewRuleAttrs(kind string) map[string]interface{} {
+	var attrs map[string]interface{}
+	if c.base != nil {
+		attrs = c.base.GetNewRuleAttrs(kind)
+	}
+	if len(c.NewRuleAttrs[kind]) == 0 {
+		return attrs
+	}
+
+	ret := make(map[string]interface{}, len(attrs)+len(c.NewRuleAttrs[kind]))
+	for name, value := range attrs {
+		ret[name] = value
+	}
+	for name, value := range c.NewRuleAttrs[kind] {
+		ret[name] = value
+	}
+	return ret
+}
+
+// ShouldErrorOnMultiplePackages returns whether a directory containing sources for more than one (non-main) package is
+// an error. Otherwise, puku warns about this and generates a library for each package.
+func (c *Config) ShouldErrorOnMultiplePackages() bool {
+	if c.ErrorOnMultiplePackages != nil {
+		return *c.ErrorOnMultiplePackages
+	}
+	if c.base != nil {
+		return c.base.ShouldErrorOnMultiplePackages()
+	}
+	return false
+}
+
 func (c *Config) isExcludedDefaultKind(kind string) bool {
 	for _, c := range c.ExcludeBuiltinKinds {
 		if c == kind {
@@ -169,34 +382,36 @@ func (c *Config) isExcludedDefaultKind(kind string) bool {
 	return c.base.isExcludedDefaultKind(kind)
 }
 
+func (kc *KindConfig) kind(name string, kindType kinds.Type) *kinds.Kind {
+	return &kinds.Kind{
+		Name:              name,
+		Type:              kindType,
+		ProvidedDeps:      kc.ProvidedDeps,
+		SrcsAttr:          kc.srcsArg(),
+		DataAttr:          kc.dataArg(),
+		EmbedAttr:         kc.embedArg(),
+		TestData:          kindType.IsTest() && (kc.TestData == nil || *kc.TestData),
+		DefaultVisibility: kc.DefaultVisibility,
+		NonGoSources:      kc.NonGoSources,
+		ImportRules:       kc.ImportRules,
+	}
+}
+
 func (c *Config) GetKind(kind string) *kinds.Kind {
 	if k, ok := c.LibKinds[kind]; ok {
-		return &kinds.Kind{
-			Name:              kind,
-			Type:              kinds.Lib,
-			ProvidedDeps:      k.ProvidedDeps,
-			SrcsAttr:          k.srcsArg(),
-			DefaultVisibility: k.DefaultVisibility,
-			NonGoSources:      k.NonGoSources,
-		}
+		return k.kind(kind, kinds.Lib)
 	}
 	if k, ok := c.TestKinds[kind]; ok {
-		return &kinds.Kind{
-			Name:         kind,
-			Type:         kinds.Test,
-			ProvidedDeps: k.ProvidedDeps,
-			SrcsAttr:     k.srcsArg(),
-			NonGoSources: k.NonGoSources,
-		}
+		return k.kind(kind, kinds.Test)
 	}
 	if k, ok := c.BinKinds[kind]; ok {
-		return &kinds.Kind{
-			Name:         kind,
-			Type:         kinds.Bin,
-			ProvidedDeps: k.ProvidedDeps,
-			SrcsAttr:     k.srcsArg(),
-			NonGoSources: k.NonGoSources,
-		}
+		return k.kind(kind, kinds.Bin)
+	}
+	if k, ok := c.BenchmarkKinds[kind]; ok {
+		return k.kind(kind, kinds.Benchmark)
+	}
+	if k, ok := c.FuzzKinds[kind]; ok {
+		return k.kind(kind, kinds.Fuzz)
 	}
 	if c.base != nil {
 		return c.base.GetKind(kind)
@@ -10,12 +10,14 @@ import (
 )
 
 func TestGetKind(t *testing.T) {
+	noTestData := false
 	c := Config{
 		LibKinds: map[string]*KindConfig{
 			"go_binary": {},
 		},
 		TestKinds: map[string]*KindConfig{
 			"service_acceptance_test": {},
+			"golden_test":             {DataArg: "golden_data", TestData: &noTestData},
 		},
 		ExcludeBuiltinKinds: []string{"proto_library"},
 	}
@@ -40,6 +42,15 @@ func TestGetKind(t *testing.T) {
 		kind := c.GetKind("service_acceptance_test")
 		require.NotNil(t, kind)
 		assert.Equal(t, kinds.Test, kind.Type)
+		assert.Equal(t, "data", kind.DataAttr)
+		assert.True(t, kind.TestData)
+	})
+
+	t.Run("custom kind with test data disabled", func(t *testing.T) {
+		kind := c.GetKind("golden_test")
+		require.NotNil(t, kind)
+		assert.Equal(t, "golden_data", kind.DataAttr)
+		assert.False(t, kind.TestData)
 	})
 }
 
@@ -82,3 +93,138 @@ func TestGetStop(t *testing.T) {
 		})
 	})
 }
+
+func TestTargetName(t *testing.T) {
+	base := &Config{NameTemplates: map[string]string{"lib": "lib", "bin": "{{.Dir}}"}}
+	c := &Config{base: base, NameTemplates: map[string]string{"test": "{{.Package}}_test"}}
+
+	t.Run("defaults", func(t *testing.T) {
+		name, err := new(Config).TargetName(kinds.Lib, "foo/bar", "baz")
+		require.NoError(t, err)
+		assert.Equal(t, "bar", name)
+
+		name, err = new(Config).TargetName(kinds.Bin, "foo/bar", "main")
+		require.NoError(t, err)
+		assert.Equal(t, "main", name)
+	})
+
+	t.Run("templates from the config chain", func(t *testing.T) {
+		name, err := c.TargetName(kinds.Lib, "foo/bar", "baz")
+		require.NoError(t, err)
+		assert.Equal(t, "lib", name)
+
+		name, err = c.TargetName(kinds.Test, "foo/bar", "baz")
+		require.NoError(t, err)
+		assert.Equal(t, "baz_test", name)
+
+		name, err = c.TargetName(kinds.Bin, "foo/bar", "main")
+		require.NoError(t, err)
+		assert.Equal(t, "bar", name)
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		c := &Config{NameTemplates: map[string]string{"lib": "{{.Missing}}", "test": ""}}
+
+		_, err := c.TargetName(kinds.Lib, "foo/bar", "baz")
+		assert.Error(t, err)
+
+		_, err = c.TargetName(kinds.Test, "foo/bar", "baz")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetDefaultKind(t *testing.T) {
+	base := &Config{
+		TestKinds:    map[string]*KindConfig{"company_go_test": {ProvidedDeps: []string{"//common/go:testing"}}},
+		DefaultKinds: map[string]string{"test": "company_go_test"},
+	}
+	c := &Config{base: base, DefaultKinds: map[string]string{"bin": "company_go_test", "lib": "missing_library"}}
+
+	t.Run("built-in kinds by default", func(t *testing.T) {
+		kind, err := base.GetDefaultKind(kinds.Lib)
+		require.NoError(t, err)
+		assert.Equal(t, "go_library", kind.Name)
+	})
+
+	t.Run("configured kinds from the config chain", func(t *testing.T) {
+		kind, err := c.GetDefaultKind(kinds.Test)
+		require.NoError(t, err)
+		assert.Equal(t, "company_go_test", kind.Name)
+		assert.Equal(t, kinds.Test, kind.Type)
+		assert.Equal(t, []string{"//common/go:testing"}, kind.ProvidedDeps)
+	})
+
+	t.Run("unknown kinds", func(t *testing.T) {
+		_, err := c.GetDefaultKind(kinds.Lib)
+		assert.Error(t, err)
+	})
+
+	t.Run("kinds of the wrong type", func(t *testing.T) {
+		_, err := c.GetDefaultKind(kinds.Bin)
+		assert.Error(t, err)
+	})
+}
+
+func TestDependencyRules(t *testing.T) {
+	base := &Config{DependencyRules: []*DependencyRule{
+		{
+			Name:     "services can't depend on tools",
+			From:     []string{"//services/..."},
+			DenyDeps: []string{"//tools/..."},
+		},
+	}}
+	c := &Config{base: base, DependencyRules: []*DependencyRule{
+		{
+			Name:         "only platform/db can use database drivers",
+			ExceptFrom:   []string{"//platform/db:all"},
+			DenyImports:  []string{"github.com/lib/pq/...", "github.com/go-sql-driver/mysql"},
+			AllowImports: []string{"github.com/lib/pq/oid"},
+		},
+	}}
+
+	rules := c.GetDependencyRules()
+	require.Len(t, rules, 2)
+	services, db := rules[0], rules[1]
+
+	t.Run("label patterns", func(t *testing.T) {
+		assert.True(t, services.AppliesTo("//services/foo:bar"))
+		assert.True(t, services.AppliesTo("//services"))
+		assert.False(t, services.AppliesTo("//servicesfoo"))
+		assert.False(t, services.AppliesTo("//tools/foo"))
+
+		assert.True(t, services.DeniesDep("//tools/foo"))
+		assert.True(t, services.DeniesDep("//tools:lint"))
+		assert.False(t, services.DeniesDep("//common/tools"))
+	})
+
+	t.Run("import patterns", func(t *testing.T) {
+		assert.True(t, db.AppliesTo("//services/foo"))
+		assert.False(t, db.AppliesTo("//platform/db:db"))
+		assert.False(t, db.AppliesTo("//platform/db"))
+
+		assert.True(t, db.DeniesImport("github.com/lib/pq"))
+		assert.True(t, db.DeniesImport("github.com/lib/pq/hstore"))
+		assert.False(t, db.DeniesImport("github.com/lib/pq/oid"))
+		assert.True(t, db.DeniesImport("github.com/go-sql-driver/mysql"))
+		assert.False(t, db.DeniesImport("github.com/go-sql-driver/mysql/other"))
+	})
+}
+
+func TestInvalidateConfigs(t *testing.T) {
+	configs = map[string]*Config{
+		".":       new(Config),
+		"foo":     new(Config),
+		"foo/bar": new(Config),
+		"foobar":  new(Config),
+	}
+	t.Cleanup(func() { configs = map[string]*Config{} })
+
+	InvalidateConfigs("foo")
+	assert.Contains(t, configs, ".")
+	assert.Contains(t, configs, "foobar")
+	assert.NotContains(t, configs, "foo")
+	assert.NotContains(t, configs, "foo/bar")
+
+	InvalidateConfigs(".")
+	assert.Empty(t, configs)
+}
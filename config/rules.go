@@ -0,0 +1,98 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// DependencyRule restricts what targets can depend on, or what their sources can import. For example, a rule could
+// stop anything under //services/... depending on //tools/..., or only allow //platform/db to import database drivers.
+//
+// Label patterns can be a label (e.g. //foo:bar or //foo), all the targets in a package (//foo:all), or all the targets
+// in a package and its subpackages (//foo/...). Import patterns can be an import path, or a path ending in /... to match
+// the package and all the packages under it.
+type DependencyRule struct {
+	// Name identifies the rule when reporting violations
+	Name string `json:"name"`
+	// From are patterns for the targets this rule applies to. The rule applies to all targets if this is empty.
+	From []string `json:"from"`
+	// ExceptFrom are patterns for targets the rule doesn't apply to, even if they match From
+	ExceptFrom []string `json:"exceptFrom"`
+	// DenyDeps are patterns for the targets that can't be depended on
+	DenyDeps []string `json:"denyDeps"`
+	// AllowDeps are patterns for targets that can be depended on, even if they match DenyDeps
+	AllowDeps []string `json:"allowDeps"`
+	// DenyImports are patterns for the import paths that can't be imported
+	DenyImports []string `json:"denyImports"`
+	// AllowImports are patterns for import paths that can be imported, even if they match DenyImports
+	AllowImports []string `json:"allowImports"`
+}
+
+// GetDependencyRules returns the dependency rules from this config and all the configs above it
+func (c *Config) GetDependencyRules() []*DependencyRule {
+	var rules []*DependencyRule
+	if c.base != nil {
+		rules = c.base.GetDependencyRules()
+	}
+	return append(rules, c.DependencyRules...)
+}
+
+// AppliesTo returns whether the rule applies to the target with the given label
+func (r *DependencyRule) AppliesTo(label string) bool {
+	if len(r.From) > 0 && !matchesAny(r.From, label, matchLabel) {
+		return false
+	}
+	return !matchesAny(r.ExceptFrom, label, matchLabel)
+}
+
+// DeniesDep returns whether the rule stops targets depending on the target with the given label
+func (r *DependencyRule) DeniesDep(label string) bool {
+	return matchesAny(r.DenyDeps, label, matchLabel) && !matchesAny(r.AllowDeps, label, matchLabel)
+}
+
+// DeniesImport returns whether the rule stops targets importing the given import path
+func (r *DependencyRule) DeniesImport(importPath string) bool {
+	return matchesAny(r.DenyImports, importPath, matchImport) && !matchesAny(r.AllowImports, importPath, matchImport)
+}
+
+func matchesAny(patterns []string, s string, match func(pattern, s string) bool) bool {
+	for _, pattern := range patterns {
+		if match(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabel returns whether the label matches the pattern
+func matchLabel(pattern, label string) bool {
+	pkg, name, hasName := strings.Cut(label, ":")
+	if !hasName {
+		name = filepath.Base(pkg)
+	}
+
+	if base, ok := strings.CutSuffix(pattern, "/..."); ok {
+		// //... matches everything in the repo
+		if base == "/" {
+			return strings.HasPrefix(pkg, "//")
+		}
+		return pkg == base || strings.HasPrefix(pkg, base+"/")
+	}
+	if patternPkg, ok := strings.CutSuffix(pattern, ":all"); ok {
+		return pkg == patternPkg
+	}
+
+	patternPkg, patternName, hasName := strings.Cut(pattern, ":")
+	if !hasName {
+		patternName = filepath.Base(patternPkg)
+	}
+	return pkg == patternPkg && name == patternName
+}
+
+// matchImport returns whether the import path matches the pattern
+func matchImport(pattern, importPath string) bool {
+	if base, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return importPath == base || strings.HasPrefix(importPath, base+"/")
+	}
+	return importPath == pattern
+}
@@ -0,0 +1,31 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// Send sends a request to the daemon, returning false if the daemon isn't running
+func Send(req *Request) (*Response, bool, error) {
+	conn, err := net.Dial("unix", SocketPath)
+	if err != nil {
+		// The daemon isn't running, or has left a stale socket behind
+		return nil, false, nil
+	}
+	defer conn.Close()
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, true, err
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return nil, true, fmt.Errorf("failed to send request to the puku daemon: %w", err)
+	}
+
+	resp := new(Response)
+	if err := json.NewDecoder(conn).Decode(resp); err != nil {
+		return nil, true, fmt.Errorf("failed to read response from the puku daemon: %w", err)
+	}
+	return resp, true, nil
+}
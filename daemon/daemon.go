@@ -0,0 +1,192 @@
+// Package daemon implements puku's daemon mode, where puku keeps its state warm between requests made over a unix
+// socket. This saves tools like pre-commit hooks and editor plugins from querying the Please config, and reading the
+// third party modules, every time they run puku.
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/please-build/puku/generate"
+	"github.com/please-build/puku/logging"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+var log = logging.GetLogger()
+
+// SocketPath is where the daemon listens, relative to the repo root
+const SocketPath = "plz-out/puku/daemon.sock"
+
+// The commands the daemon can run
+const (
+	CommandUpdate = "update"
+	CommandLint   = "lint"
+	CommandWhy    = "why"
+)
+
+// Request is a request to the daemon. Each connection sends a single request, followed by a newline, and gets a single
+// response back.
+type Request struct {
+	// Command is the command to run, i.e. update, lint or why
+	Command string
+	// Paths are the package directories to update or lint, relative to the repo root
+	Paths []string
+	// Format is the output format for lint, i.e. text or json
+	Format string
+	// Import is the import path to resolve for why, from the package in Dir
+	Import, Dir string
+	// Options are the global options the client was run with
+	Options options.Options
+}
+
+// Response is the response to a request
+type Response struct {
+	// Output is what the command would have written to stdout
+	Output string
+	// Warnings are the warnings puku logged while running the command
+	Warnings []string
+	// Error is the error that stopped the command, if it failed
+	Error string
+	// ExitCode is the code puku would have exited with if it ran the command itself
+	ExitCode int
+}
+
+// Server serves requests from a single session
+type Server struct {
+	session *generate.Session
+	// mux serialises requests, as sessions can't be used concurrently
+	mux sync.Mutex
+}
+
+// NewServer creates a new server
+func NewServer(plzConf *please.Config, opts options.Options) *Server {
+	return &Server{session: generate.NewSession(plzConf, opts)}
+}
+
+// Serve listens on the socket, serving requests until puku receives SIGINT or SIGTERM
+func Serve(plzConf *please.Config, opts options.Options) error {
+	if conn, err := net.Dial("unix", SocketPath); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("puku daemon is already running on %v", SocketPath)
+	}
+	// Clean up the socket left behind if the daemon didn't exit cleanly
+	if err := os.Remove(SocketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(SocketPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("unix", SocketPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// This also removes the socket
+		if err := listener.Close(); err != nil {
+			log.Warningf("failed to close socket: %v", err)
+		}
+	}()
+
+	s := NewServer(plzConf, opts)
+	log.Infof("Listening on %v", SocketPath)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	req := new(Request)
+	resp := new(Response)
+	if line, err := bufio.NewReader(conn).ReadBytes('\n'); err != nil {
+		resp.Error = fmt.Sprintf("failed to read request: %v", err)
+		resp.ExitCode = 1
+	} else if err := json.Unmarshal(line, req); err != nil {
+		resp.Error = fmt.Sprintf("invalid request: %v", err)
+		resp.ExitCode = 1
+	} else {
+		resp = s.Handle(req)
+	}
+
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		log.Warningf("failed to write response: %v", err)
+	}
+}
+
+// Handle runs a request against the session
+func (s *Server) Handle(req *Request) *Response {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var resp *Response
+	warnings := logging.Capture(func() {
+		resp = s.handle(req)
+	})
+	resp.Warnings = warnings
+	return resp
+}
+
+func (s *Server) handle(req *Request) *Response {
+	s.session.SetOptions(req.Options)
+
+	// Nothing tells us what's changed between requests, so we have to assume anything could have
+	if err := s.session.Refresh(); err != nil {
+		return &Response{Error: err.Error(), ExitCode: 1}
+	}
+
+	out := new(bytes.Buffer)
+	var err error
+	switch req.Command {
+	case CommandUpdate:
+		err = s.session.Update(req.Paths...)
+	case CommandLint:
+		err = s.session.Lint(out, req.Format, req.Paths...)
+		if errors.Is(err, generate.ErrIssuesFound) {
+			return &Response{Output: out.String(), ExitCode: 1}
+		}
+	case CommandWhy:
+		var target string
+		if target, err = s.session.Why(req.Dir, req.Import); err == nil {
+			out.WriteString(formatWhy(req.Import, target))
+		}
+	default:
+		err = fmt.Errorf("unknown command %v", req.Command)
+	}
+
+	if err != nil {
+		return &Response{Output: out.String(), Error: err.Error(), ExitCode: 1}
+	}
+	return &Response{Output: out.String()}
+}
+
+// formatWhy formats the result of resolving an import for why
+func formatWhy(importPath, target string) string {
+	if target == "" {
+		return fmt.Sprintf("%v doesn't need a dependency\n", importPath)
+	}
+	return fmt.Sprintf("%v resolves to %v\n", importPath, target)
+}
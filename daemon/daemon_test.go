@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+func TestServeConn(t *testing.T) {
+	s := NewServer(new(please.Config), options.TestOptions)
+
+	client, server := net.Pipe()
+	defer client.Close()
+	go s.serveConn(server)
+
+	data, err := json.Marshal(&Request{Command: "explode"})
+	require.NoError(t, err)
+	_, err = client.Write(append(data, '\n'))
+	require.NoError(t, err)
+
+	resp := new(Response)
+	require.NoError(t, json.NewDecoder(bufio.NewReader(client)).Decode(resp))
+	assert.Equal(t, &Response{Error: "unknown command explode", ExitCode: 1, Warnings: []string{}}, resp)
+}
+
+func TestFormatWhy(t *testing.T) {
+	assert.Equal(t, "fmt doesn't need a dependency\n", formatWhy("fmt", ""))
+	assert.Equal(t, "github.com/example/foo resolves to //foo:foo\n", formatWhy("github.com/example/foo", "//foo:foo"))
+}
@@ -2,6 +2,10 @@ package edit
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/please-build/buildtools/build"
@@ -91,6 +95,54 @@ func NewStringList(ss []string) *build.ListExpr {
 	return l
 }
 
+// NewValueExpr converts a value decoded from JSON into the equivalent build expression, e.g. a []interface{} of strings
+// into a list of strings.
+func NewValueExpr(v interface{}) (build.Expr, error) {
+	switch v := v.(type) {
+	case nil:
+		return &build.Ident{Name: "None"}, nil
+	case bool:
+		if v {
+			return &build.Ident{Name: "True"}, nil
+		}
+		return &build.Ident{Name: "False"}, nil
+	case string:
+		return NewStringExpr(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		return &build.LiteralExpr{Token: strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []interface{}:
+		l := new(build.ListExpr)
+		for _, elem := range v {
+			expr, err := NewValueExpr(elem)
+			if err != nil {
+				return nil, err
+			}
+			l.List = append(l.List, expr)
+		}
+		return l, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		dict := new(build.DictExpr)
+		for _, key := range keys {
+			expr, err := NewValueExpr(v[key])
+			if err != nil {
+				return nil, err
+			}
+			dict.List = append(dict.List, &build.KeyValueExpr{Key: NewStringExpr(key), Value: expr})
+		}
+		return dict, nil
+	}
+	return nil, fmt.Errorf("unsupported value %v of type %T", v, v)
+}
+
 func NewRuleExpr(kind, name string) *build.Rule {
 	rule, _ := edit.ExprToRule(&build.CallExpr{
 		X:    &build.Ident{Name: kind},
@@ -1,11 +1,15 @@
 package edit
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/please-build/buildtools/build"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/kinds"
 )
 
 func TestEnsureSubinclude(t *testing.T) {
@@ -62,3 +66,139 @@ func TestEnsureSubinclude(t *testing.T) {
 		assert.Equal(t, file.Stmt[0], subinc)
 	})
 }
+
+func TestSetManagedValues(t *testing.T) {
+	isManaged := func(v string) bool {
+		return strings.HasPrefix(v, "testdata/")
+	}
+
+	t.Run("replaces managed values", func(t *testing.T) {
+		rule := NewRule(NewRuleExpr("go_test", "foo_test"), nil, "foo")
+		rule.SetAttr("data", NewStringList([]string{"//other:data", "testdata/old"}))
+
+		rule.SetManagedValues("data", []string{"testdata/new"}, isManaged)
+		assert.Equal(t, []string{"//other:data", "testdata/new"}, rule.AttrStrings("data"))
+	})
+
+	t.Run("deletes the attribute when empty", func(t *testing.T) {
+		rule := NewRule(NewRuleExpr("go_test", "foo_test"), nil, "foo")
+		rule.SetAttr("data", NewStringList([]string{"testdata/old"}))
+
+		rule.SetManagedValues("data", nil, isManaged)
+		assert.Nil(t, rule.Attr("data"))
+	})
+
+	t.Run("leaves non-list attributes alone", func(t *testing.T) {
+		rule := NewRule(NewRuleExpr("go_test", "foo_test"), nil, "foo")
+		glob := &build.CallExpr{X: &build.Ident{Name: "glob"}, List: []build.Expr{NewStringList([]string{"testdata/*"})}}
+		rule.SetAttr("data", glob)
+
+		rule.SetManagedValues("data", []string{"testdata/new"}, isManaged)
+		assert.Equal(t, glob, rule.Attr("data"))
+	})
+}
+
+func TestAddAndRemoveSrc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		srcs     string
+		add      string
+		remove   string
+		expected string
+	}{
+		{
+			name: "adds to a list",
+			srcs: `["foo.go"]`,
+			add:  "bar.go",
+			expected: `[
+    "foo.go",
+    "bar.go",
+]`,
+		},
+		{
+			name: "adds to the list concatenated onto a glob",
+			srcs: `glob(["*.go"]) + ["foo.go"]`,
+			add:  "bar.go",
+			expected: `glob(["*.go"]) + [
+    "foo.go",
+    "bar.go",
+]`,
+		},
+		{
+			name:     "concatenates a list onto a variable",
+			srcs:     `SRCS`,
+			add:      "bar.go",
+			expected: `SRCS + ["bar.go"]`,
+		},
+		{
+			name:     "removes from a list",
+			srcs:     `["foo.go", "bar.go"]`,
+			remove:   "bar.go",
+			expected: `["foo.go"]`,
+		},
+		{
+			name:     "removes lists concatenated onto a variable when they're empty",
+			srcs:     `SRCS + ["bar.go"] + select({"//config:linux": ["linux.go"]})`,
+			remove:   "bar.go",
+			expected: `SRCS + select({"//config:linux": ["linux.go"]})`,
+		},
+		{
+			name:     "leaves variables alone",
+			srcs:     `SRCS`,
+			remove:   "bar.go",
+			expected: `SRCS`,
+		},
+		{
+			name:     "deletes the attribute when empty",
+			srcs:     `["bar.go"]`,
+			remove:   "bar.go",
+			expected: ``,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := build.ParseBuild("BUILD", []byte(`go_library(name = "foo", srcs = `+test.srcs+`)`))
+			require.NoError(t, err)
+			rule := NewRule(file.Rules("go_library")[0], kinds.DefaultKinds["go_library"], "foo")
+
+			if test.add != "" {
+				rule.AddSrc(test.add)
+			}
+			if test.remove != "" {
+				rule.RemoveSrc(test.remove)
+			}
+
+			if test.expected == "" {
+				assert.Nil(t, rule.Attr("srcs"))
+			} else {
+				assert.Equal(t, test.expected, build.FormatString(rule.Attr("srcs")))
+			}
+		})
+	}
+}
+
+func TestNewValueExpr(t *testing.T) {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"labels": ["unit", "fast"], "size": "small", "flaky": true, "timeout": 60, "env": {"B": "2", "A": null}}`), &value))
+
+	expr, err := NewValueExpr(value)
+	require.NoError(t, err)
+	expected := `{
+    "env": {
+        "A": None,
+        "B": "2",
+    },
+    "flaky": True,
+    "labels": [
+        "unit",
+        "fast",
+    ],
+    "size": "small",
+    "timeout": 60,
+}`
+	assert.Equal(t, expected, build.FormatString(expr))
+
+	_, err = NewValueExpr(1.5)
+	assert.Error(t, err)
+}
@@ -56,30 +56,106 @@ func (rule *Rule) SetOrDeleteAttr(name string, values []string) {
 	rule.SetAttr(name, listExpr)
 }
 
+// SetManagedValues makes sure the values in the list attribute that isManaged returns true for match the values passed
+// in. Other values in the list are left alone, so this can be used for attributes where puku only manages some of the
+// values. Attributes that aren't a list of strings are left alone, as we can't safely update them.
+func (rule *Rule) SetManagedValues(name string, values []string, isManaged func(string) bool) {
+	attr := rule.Attr(name)
+	if attr != nil {
+		if _, ok := attr.(*build.ListExpr); !ok {
+			return
+		}
+	}
+
+	existing := rule.AttrStrings(name)
+	set := make([]string, 0, len(existing)+len(values))
+	for _, v := range existing {
+		if !isManaged(v) {
+			set = append(set, v)
+		}
+	}
+	rule.SetOrDeleteAttr(name, append(set, values...))
+}
+
 func (rule *Rule) IsTest() bool {
-	return rule.Kind.Type == kinds.Test
+	return rule.Kind.Type.IsTest()
 }
 
 func (rule *Rule) SrcsAttr() string {
 	return rule.Kind.SrcsAttr
 }
 
+func (rule *Rule) DataAttr() string {
+	return rule.Kind.DataAttr
+}
+
+func (rule *Rule) EmbedAttr() string {
+	return rule.Kind.EmbedAttr
+}
+
+// AddSrc adds a source to the rule. If the sources aren't a plain list, e.g. because they use a variable or a glob, the
+// source is added to a list concatenated onto them, leaving the rest of the expression alone.
 func (rule *Rule) AddSrc(src string) {
 	srcsAttr := rule.SrcsAttr()
-	srcs := rule.AttrStrings(srcsAttr)
-	rule.SetOrDeleteAttr(srcsAttr, append(srcs, src))
+	srcs := rule.Attr(srcsAttr)
+	if srcs == nil {
+		rule.SetAttr(srcsAttr, NewStringList([]string{src}))
+		return
+	}
+
+	list, ok := srcs.(*build.ListExpr)
+	if bin, isBinary := srcs.(*build.BinaryExpr); isBinary && bin.Op == "+" {
+		list, ok = bin.Y.(*build.ListExpr)
+	}
+	if ok {
+		list.List = append(list.List, NewStringExpr(src))
+		return
+	}
+	rule.SetAttr(srcsAttr, &build.BinaryExpr{X: srcs, Op: "+", Y: NewStringList([]string{src})})
 }
 
+// RemoveSrc removes a source from the lists of strings in the rule's sources. Sources that come from elsewhere, e.g.
+// variables or globs, are left alone.
 func (rule *Rule) RemoveSrc(rem string) {
 	srcsAttr := rule.SrcsAttr()
-	srcs := rule.AttrStrings(srcsAttr)
-	set := make([]string, 0, len(srcs))
-	for _, src := range srcs {
-		if src != rem {
-			set = append(set, src)
+	if srcs := removeString(rule.Attr(srcsAttr), rem); srcs != nil {
+		rule.SetAttr(srcsAttr, srcs)
+	} else {
+		rule.DelAttr(srcsAttr)
+	}
+}
+
+// removeString removes a string from the lists in a list expression, or lists concatenated with +. It returns the
+// resulting expression, which is nil if nothing is left.
+func removeString(expr build.Expr, rem string) build.Expr {
+	switch expr := expr.(type) {
+	case *build.ListExpr:
+		list := make([]build.Expr, 0, len(expr.List))
+		for _, e := range expr.List {
+			if s, ok := e.(*build.StringExpr); ok && s.Value == rem {
+				continue
+			}
+			list = append(list, e)
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		expr.List = list
+		return expr
+	case *build.BinaryExpr:
+		if expr.Op != "+" {
+			return expr
+		}
+		expr.X, expr.Y = removeString(expr.X, rem), removeString(expr.Y, rem)
+		if expr.X == nil {
+			return expr.Y
+		}
+		if expr.Y == nil {
+			return expr.X
 		}
+		return expr
 	}
-	rule.SetOrDeleteAttr(srcsAttr, set)
+	return expr
 }
 
 func (rule *Rule) LocalLabel() string {
@@ -13,14 +13,54 @@ import (
 
 type Eval struct {
 	globber *glob.Globber
+	// loadFile loads the build file for a package, so we can look up the variables it defines
+	loadFile func(dir string) (*build.File, error)
+
+	// targets and outs cache what we've learnt from plz about the targets used as sources, so we only have to ask once
+	targets map[string]*please.TargetInfo
+	outs    map[string][]string
+	// srcs are the srcs of targets we've queried from plz, keyed by directory then target name
+	srcs map[string]map[string][]string
 }
 
 func New(globber *glob.Globber) *Eval {
 	return &Eval{
 		globber: globber,
+		targets: map[string]*please.TargetInfo{},
+		outs:    map[string][]string{},
+		srcs:    map[string]map[string][]string{},
 	}
 }
 
+// WithFileLoader sets how to load the build file for a package. Without this, rules that refer to variables in their
+// build file can't be evaluated.
+func (e *Eval) WithFileLoader(loadFile func(dir string) (*build.File, error)) *Eval {
+	e.loadFile = loadFile
+	return e
+}
+
+// Invalidate removes any cached results for a directory, e.g. because files have been added or removed
+func (e *Eval) Invalidate(dir string) {
+	e.globber.Invalidate(dir)
+	delete(e.srcs, dir)
+}
+
+// RemoveQueriedSource removes a src from the srcs queried from plz for a target, e.g. because puku has removed it from
+// the rule. Sources puku adds don't need this, as they're picked up from the rule.
+func (e *Eval) RemoveQueriedSource(dir, target, src string) {
+	srcs, ok := e.srcs[dir][target]
+	if !ok {
+		return
+	}
+	ret := make([]string, 0, len(srcs))
+	for _, s := range srcs {
+		if s != src {
+			ret = append(ret, s)
+		}
+	}
+	e.srcs[dir][target] = ret
+}
+
 func LookLikeBuildLabel(l string) bool {
 	if strings.HasPrefix(l, "@") {
 		return true
@@ -28,37 +68,21 @@ func LookLikeBuildLabel(l string) bool {
 	if strings.HasPrefix(l, ":") {
 		return true
 	}
-	return strings.HasPrefix("//", l)
+	return strings.HasPrefix(l, "//")
 }
 
+// EvalGlobs evaluates the attribute of the rule to a list of strings, expanding any globs. If the srcs of the rule's
+// package have been queried from plz, those are used instead.
 func (e *Eval) EvalGlobs(dir string, rule *build.Rule, attrName string) ([]string, error) {
-	return e.evalGlobs(dir, rule.Attr(attrName))
-}
+	if srcs, ok := e.queriedSources(dir, rule, attrName); ok {
+		return srcs, nil
+	}
 
-func (e *Eval) evalGlobs(dir string, val build.Expr) ([]string, error) {
-	switch expr := val.(type) {
-	case *build.CallExpr:
-		globArgs := parseGlob(expr)
-		if globArgs == nil {
-			return nil, nil
-		}
-		return e.globber.Glob(dir, globArgs)
-	case *build.BinaryExpr:
-		if expr.Op != "+" {
-			return nil, fmt.Errorf("encountered a binary expression with operation %s. Only + is supported", expr.Op)
-		}
-		x, err := e.evalGlobs(dir, expr.X)
-		if err != nil {
-			return nil, err
-		}
-		y, err := e.evalGlobs(dir, expr.Y)
-		if err != nil {
-			return nil, err
-		}
-		return append(x, y...), nil
-	default:
-		return build.Strings(expr), nil
+	ret, err := e.newEvaluator(dir, rule).strings(rule.Attr(attrName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %v of %v in %v: %w", attrName, rule.Name(), dir, err)
 	}
+	return ret, nil
 }
 
 func (e *Eval) BuildSources(plzPath, dir string, rule *build.Rule, srcsArg string) ([]string, error) {
@@ -66,22 +90,26 @@ func (e *Eval) BuildSources(plzPath, dir string, rule *build.Rule, srcsArg strin
 	if err != nil {
 		return nil, err
 	}
+
+	var srcLabels []string
+	for _, src := range srcs {
+		if LookLikeBuildLabel(src) {
+			srcLabels = append(srcLabels, labels.ParseRelative(src, dir).Format())
+		}
+	}
+	if err := e.Prefetch(plzPath, srcLabels); err != nil {
+		return nil, err
+	}
+
 	ret := make([]string, 0, len(srcs))
 	for _, src := range srcs {
 		if !LookLikeBuildLabel(src) {
 			ret = append(ret, src)
 			continue
 		}
-		targets, err := please.RecursivelyProvide(plzPath, labels.ParseRelative(src, dir).Format(), "go")
-		if err != nil {
-			return nil, err
-		}
-
-		outs, err := please.Build(plzPath, targets...)
-		if err != nil {
-			return nil, err
+		for _, target := range e.provided(labels.ParseRelative(src, dir).Format()) {
+			ret = append(ret, e.outs[target]...)
 		}
-		ret = append(ret, outs...)
 	}
 	return ret, nil
 }
@@ -101,34 +129,43 @@ func parseGlob(srcs build.Expr) *glob.Args {
 		return nil
 	}
 
-	var include, exclude []string
+	// These are the defaults for Please's glob builtin
+	args := &glob.Args{IncludeSymlinks: true}
 	positionalPos := 0
 	for _, expr := range call.List {
 		assign, ok := expr.(*build.AssignExpr)
 		if ok {
-			ident := assign.LHS.(*build.Ident)
+			ident, ok := assign.LHS.(*build.Ident)
 			if !ok {
 				return nil
 			}
-			if ident.Name == "include" {
-				include = build.Strings(assign.RHS)
+			switch ident.Name {
+			case "include":
+				args.Include = build.Strings(assign.RHS)
+			case "exclude":
+				args.Exclude = build.Strings(assign.RHS)
+			case "hidden":
+				args.Hidden = isTrue(assign.RHS)
+			case "include_symlinks":
+				args.IncludeSymlinks = isTrue(assign.RHS)
+			case "exclude_directories":
+				args.IncludeDirectories = !isTrue(assign.RHS)
 			}
-			if ident.Name == "exclude" {
-				exclude = build.Strings(assign.RHS)
-			}
-			continue // ignore other args. We don't care about include_symlinks etc.
+			continue // ignore other args, e.g. allow_empty
 		}
 
 		if positionalPos == 0 {
-			include = build.Strings(expr)
+			args.Include = build.Strings(expr)
 		}
 		if positionalPos == 1 {
-			exclude = build.Strings(expr)
+			args.Exclude = build.Strings(expr)
 		}
 		positionalPos++
 	}
-	return &glob.Args{
-		Include: include,
-		Exclude: exclude,
-	}
+	return args
+}
+
+func isTrue(expr build.Expr) bool {
+	ident, ok := expr.(*build.Ident)
+	return ok && ident.Name == "True"
 }
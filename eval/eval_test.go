@@ -1,6 +1,9 @@
 package eval
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/please-build/buildtools/build"
@@ -8,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/please-build/puku/glob"
+	"github.com/please-build/puku/please"
 )
 
 func TestParseGlob(t *testing.T) {
@@ -57,8 +61,26 @@ func TestParseGlob(t *testing.T) {
 		})
 	}
 }
+func TestParseGlobOptions(t *testing.T) {
+	parse := func(code string) *glob.Args {
+		file, err := build.ParseBuild("BUILD", []byte(code))
+		require.NoError(t, err)
+		return parseGlob(file.Stmt[0])
+	}
+
+	args := parse(`glob(["*.go"])`)
+	assert.False(t, args.Hidden)
+	assert.True(t, args.IncludeSymlinks)
+	assert.False(t, args.IncludeDirectories)
+
+	args = parse(`glob(["*.go"], hidden = True, include_symlinks = False, exclude_directories = False)`)
+	assert.True(t, args.Hidden)
+	assert.False(t, args.IncludeSymlinks)
+	assert.True(t, args.IncludeDirectories)
+}
+
 func TestEvalGlob(t *testing.T) {
-	e := New(glob.New())
+	e := New(glob.New([]string{"BUILD_FILE", "BUILD_FILE.plz"}))
 	testCases := []struct {
 		name     string
 		code     string
@@ -90,9 +112,223 @@ func TestEvalGlob(t *testing.T) {
 			file, err := build.ParseBuild(test.name, []byte(test.code))
 			require.NoError(t, err)
 			require.Len(t, file.Stmt, 1)
-			got, err := e.evalGlobs("test_project", file.Stmt[0])
+			got, err := e.newEvaluator("test_project", nil).strings(file.Stmt[0])
 			require.NoError(t, err)
 			assert.ElementsMatch(t, test.expected, got)
 		})
 	}
 }
+
+func TestEvalGlobsWithExpressions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name: "variable",
+			code: `SRCS = ["main.go"]
+go_library(name = "foo", srcs = SRCS)`,
+			expected: []string{"main.go"},
+		},
+		{
+			name: "variables concatenated with lists and globs",
+			code: `SRCS = ["main.go"]
+TEST_SRCS = glob(["*_test.go"])
+go_library(name = "foo", srcs = SRCS + TEST_SRCS + ["bar.go"])`,
+			expected: []string{"main.go", "bar_test.go", "bar.go"},
+		},
+		{
+			name: "augmented assignment",
+			code: `SRCS = ["main.go"]
+SRCS += ["bar.go"]
+go_library(name = "foo", srcs = SRCS)`,
+			expected: []string{"main.go", "bar.go"},
+		},
+		{
+			name: "uses the value of the variable at the rule",
+			code: `SRCS = ["main.go"]
+go_library(name = "foo", srcs = SRCS)
+SRCS = ["bar.go"]`,
+			expected: []string{"main.go"},
+		},
+		{
+			name: "unions the branches of a select",
+			code: `go_library(name = "foo", srcs = ["main.go"] + select({
+    "//config:linux": ["linux.go", "unix.go"],
+    "//config:darwin": ["darwin.go", "unix.go"],
+    "default": [],
+}))`,
+			expected: []string{"main.go", "linux.go", "unix.go", "darwin.go"},
+		},
+		{
+			name: "comprehension",
+			code: `NAMES = ["main", "bar"]
+go_library(name = "foo", srcs = [name + ".go" for name in NAMES])`,
+			expected: []string{"main.go", "bar.go"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := build.ParseBuild("BUILD", []byte(test.code))
+			require.NoError(t, err)
+			e := New(glob.New([]string{"BUILD_FILE", "BUILD_FILE.plz"})).WithFileLoader(func(string) (*build.File, error) {
+				return file, nil
+			})
+
+			got, err := e.EvalGlobs("test_project", file.Rules("go_library")[0], "srcs")
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, got)
+		})
+	}
+}
+
+func TestEvalGlobsErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		code string
+	}{
+		{
+			name: "undefined variable",
+			code: `go_library(name = "foo", srcs = SRCS)`,
+		},
+		{
+			name: "unsupported call",
+			code: `go_library(name = "foo", srcs = srcs_for("foo"))`,
+		},
+		{
+			name: "unsupported operation",
+			code: `go_library(name = "foo", srcs = ["main.go"] * 2)`,
+		},
+		{
+			name: "adding a string to a list",
+			code: `go_library(name = "foo", srcs = ["main.go"] + "bar.go")`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := build.ParseBuild("BUILD", []byte(test.code))
+			require.NoError(t, err)
+			e := New(glob.New([]string{"BUILD_FILE", "BUILD_FILE.plz"})).WithFileLoader(func(string) (*build.File, error) {
+				return file, nil
+			})
+
+			_, err = e.EvalGlobs("test_project", file.Rules("go_library")[0], "srcs")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLookLikeBuildLabel(t *testing.T) {
+	assert.True(t, LookLikeBuildLabel("//foo:bar"))
+	assert.True(t, LookLikeBuildLabel(":bar"))
+	assert.True(t, LookLikeBuildLabel("@foo//bar:baz"))
+	assert.False(t, LookLikeBuildLabel("bar.go"))
+	assert.False(t, LookLikeBuildLabel("bar/baz.go"))
+}
+
+// fakePlz writes a script that pretends to be plz, logging its arguments to a file
+func fakePlz(t *testing.T, queryOutput, buildOutput string) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "plz.log")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"case \"$1\" in\n" +
+		"query) echo '" + queryOutput + "' ;;\n" +
+		"build) printf '" + buildOutput + "' ;;\n" +
+		"esac\n"
+	plz := filepath.Join(dir, "plz")
+	require.NoError(t, os.WriteFile(plz, []byte(script), 0755))
+	return plz, logFile
+}
+
+func TestBuildSources(t *testing.T) {
+	plz, logFile := fakePlz(t,
+		`{"//foo:a": {"provides": {"go": ["//foo:a_go"]}}, "//foo:a_go": {"outs": ["a.go"]}, "//foo:b": {"outs": {"go": ["b.go"]}}}`,
+		`plz-out/gen/foo/a.go\nplz-out/gen/foo/b.go\n`,
+	)
+
+	file, err := build.ParseBuild("BUILD", []byte(`go_library(name = "foo", srcs = [":a", "//foo:b", "foo.go"])`))
+	require.NoError(t, err)
+	rule := file.Rules("go_library")[0]
+
+	e := New(glob.New([]string{"BUILD_FILE", "BUILD_FILE.plz"}))
+	srcs, err := e.BuildSources(plz, "foo", rule, "srcs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plz-out/gen/foo/a.go", "plz-out/gen/foo/b.go", "foo.go"}, srcs)
+
+	// The results are cached, so we shouldn't need to run plz again
+	srcs, err = e.BuildSources(plz, "foo", rule, "srcs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plz-out/gen/foo/a.go", "plz-out/gen/foo/b.go", "foo.go"}, srcs)
+
+	log, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"query print --json --field=provides --field=outs //foo:a //foo:b",
+		"query print --json --field=provides --field=outs //foo:a_go",
+		"build -p //foo:a_go //foo:b",
+	}, strings.Split(strings.TrimSpace(string(log)), "\n"))
+}
+
+func TestQuerySources(t *testing.T) {
+	plz, logFile := fakePlz(t,
+		`{"//foo:foo": {"srcs": ["foo/a.go", "foo/b.go", "//foo:gen"]}, "//foo:_foo#lib": {"srcs": ["foo/a.go"]}}`,
+		``,
+	)
+
+	file, err := build.ParseBuild("BUILD", []byte(`go_library(name = "foo", srcs = go_srcs() + ["new.go"])`))
+	require.NoError(t, err)
+	rule := file.Rules("go_library")[0]
+
+	e := New(glob.New(nil))
+	require.NoError(t, e.QuerySources(plz, "foo"))
+	require.NoError(t, e.QuerySources(plz, "foo"))
+
+	// The srcs come from plz, along with any srcs puku has added to the rule since
+	srcs, err := e.EvalGlobs("foo", rule, "srcs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go", "//foo:gen", "new.go"}, srcs)
+
+	log, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "query print --json --field=srcs //foo:all\n", string(log))
+
+	// Sources puku removes shouldn't be reported any more
+	e.RemoveQueriedSource("foo", "foo", "b.go")
+	srcs, err = e.EvalGlobs("foo", rule, "srcs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "//foo:gen", "new.go"}, srcs)
+
+	// Without the queried srcs, we have to evaluate them ourselves, which we can't do for the macro
+	e.Invalidate("foo")
+	_, err = e.EvalGlobs("foo", rule, "srcs")
+	assert.Error(t, err)
+}
+
+func TestMatchOuts(t *testing.T) {
+	outs := []string{"plz-out/gen/foo/a.go", "plz-out/bin/foo/b.go", "plz-out/gen/bar/a.go", "plz-out/gen/a.go"}
+
+	assert.Equal(t, []string{"plz-out/gen/foo/a.go", "plz-out/bin/foo/b.go"}, matchOuts("//foo:foo", []string{"a.go", "b.go"}, outs))
+	// Outputs of targets in the root package shouldn't match outputs with the same name in other packages
+	assert.Equal(t, []string{"plz-out/gen/a.go"}, matchOuts("//:root", []string{"a.go"}, outs))
+	assert.Empty(t, matchOuts("//baz:baz", []string{"a.go"}, outs))
+}
+
+func TestProvidedCycle(t *testing.T) {
+	e := New(glob.New(nil))
+	e.targets = map[string]*please.TargetInfo{
+		"//foo:a": {Provides: map[string][]string{"go": {"//foo:b"}}},
+		"//foo:b": {Provides: map[string][]string{"go": {"//foo:a", "//foo:c"}}},
+		"//foo:c": {},
+	}
+	assert.Equal(t, []string{"//foo:c"}, e.provided("//foo:a"))
+	assert.Equal(t, []string{"//foo:c"}, e.provided("//foo:b"))
+
+	e.targets["//foo:b"].Provides["go"] = []string{"//foo:a"}
+	assert.Equal(t, []string{"//foo:a"}, e.provided("//foo:a"))
+}
@@ -0,0 +1,258 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/please-build/buildtools/build"
+)
+
+// evaluator evaluates the subset of the build language that puku understands in source lists: string lists, globs,
+// variables assigned at the top level of the build file, + and +=, select() and simple list comprehensions. Values are
+// either a string or a list of strings.
+type evaluator struct {
+	e    *Eval
+	dir  string
+	rule *build.Rule
+
+	// stmts are the top level statements of the build file before the rule we're evaluating, which is where we look up
+	// variables. These are only loaded if we come across a variable.
+	stmts  []build.Expr
+	loaded bool
+}
+
+func (e *Eval) newEvaluator(dir string, rule *build.Rule) *evaluator {
+	return &evaluator{e: e, dir: dir, rule: rule}
+}
+
+// load loads the top level statements of the build file that come before the rule
+func (ev *evaluator) load() error {
+	if ev.loaded || ev.e.loadFile == nil {
+		return nil
+	}
+	file, err := ev.e.loadFile(ev.dir)
+	if err != nil {
+		return err
+	}
+	ev.loaded = true
+
+	ev.stmts = file.Stmt
+	for i, stmt := range file.Stmt {
+		if ev.rule != nil && stmt == build.Expr(ev.rule.Call) {
+			ev.stmts = file.Stmt[:i]
+			break
+		}
+	}
+	return nil
+}
+
+// strings evaluates an expression that should result in a list of strings. A single string is treated as a list
+// containing just that string.
+func (ev *evaluator) strings(expr build.Expr) ([]string, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	val, err := ev.eval(expr, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := val.(string); ok {
+		return []string{s}, nil
+	}
+	return val.([]string), nil
+}
+
+// eval evaluates an expression. Variables are looked up in the locals bound by any enclosing comprehensions, then in
+// the top level statements before pos, or all of them if pos is negative.
+func (ev *evaluator) eval(expr build.Expr, pos int, locals map[string]string) (interface{}, error) {
+	switch expr := expr.(type) {
+	case *build.StringExpr:
+		return expr.Value, nil
+	case *build.ListExpr:
+		ret := make([]string, 0, len(expr.List))
+		for _, elem := range expr.List {
+			val, err := ev.eval(elem, pos, locals)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("lists can only contain strings, got %v", build.FormatString(elem))
+			}
+			ret = append(ret, s)
+		}
+		return ret, nil
+	case *build.ParenExpr:
+		return ev.eval(expr.X, pos, locals)
+	case *build.Ident:
+		if val, ok := locals[expr.Name]; ok {
+			return val, nil
+		}
+		return ev.lookup(expr.Name, pos)
+	case *build.BinaryExpr:
+		if expr.Op != "+" {
+			return nil, fmt.Errorf("encountered a binary expression with operation %s. Only + is supported", expr.Op)
+		}
+		x, err := ev.eval(expr.X, pos, locals)
+		if err != nil {
+			return nil, err
+		}
+		y, err := ev.eval(expr.Y, pos, locals)
+		if err != nil {
+			return nil, err
+		}
+		return add(x, y)
+	case *build.CallExpr:
+		return ev.call(expr, pos, locals)
+	case *build.Comprehension:
+		return ev.comprehension(expr, pos, locals)
+	}
+	return nil, fmt.Errorf("unsupported expression %v", build.FormatString(expr))
+}
+
+// lookup returns the value of a variable assigned in the top level statements before pos
+func (ev *evaluator) lookup(name string, pos int) (interface{}, error) {
+	if err := ev.load(); err != nil {
+		return nil, err
+	}
+	if pos < 0 {
+		pos = len(ev.stmts)
+	}
+	for i := pos - 1; i >= 0; i-- {
+		assign, ok := ev.stmts[i].(*build.AssignExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := assign.LHS.(*build.Ident); !ok || ident.Name != name {
+			continue
+		}
+
+		val, err := ev.eval(assign.RHS, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate %v: %w", name, err)
+		}
+		switch assign.Op {
+		case "=":
+			return val, nil
+		case "+=":
+			prev, err := ev.lookup(name, i)
+			if err != nil {
+				return nil, err
+			}
+			return add(prev, val)
+		}
+		return nil, fmt.Errorf("unsupported assignment to %v with %v", name, assign.Op)
+	}
+	return nil, fmt.Errorf("unknown variable %v", name)
+}
+
+// call evaluates calls to glob() and select()
+func (ev *evaluator) call(expr *build.CallExpr, pos int, locals map[string]string) (interface{}, error) {
+	ident, ok := expr.X.(*build.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported call %v", build.FormatString(expr))
+	}
+
+	switch ident.Name {
+	case "glob":
+		files, err := ev.e.globber.Glob(ev.dir, parseGlob(expr))
+		if err != nil {
+			return nil, err
+		}
+		if files == nil {
+			files = []string{}
+		}
+		return files, nil
+	case "select":
+		// We don't know which branch will be chosen, so take all of them
+		if len(expr.List) == 0 {
+			return nil, fmt.Errorf("select() requires a dict of branches")
+		}
+		dict, ok := expr.List[0].(*build.DictExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported select() argument %v", build.FormatString(expr.List[0]))
+		}
+		var ret []string
+		seen := map[string]struct{}{}
+		for _, kv := range dict.List {
+			val, err := ev.eval(kv.Value, pos, locals)
+			if err != nil {
+				return nil, err
+			}
+			branch, ok := val.([]string)
+			if !ok {
+				return nil, fmt.Errorf("select() branches must be lists, got %v", build.FormatString(kv.Value))
+			}
+			for _, s := range branch {
+				if _, ok := seen[s]; !ok {
+					seen[s] = struct{}{}
+					ret = append(ret, s)
+				}
+			}
+		}
+		if ret == nil {
+			ret = []string{}
+		}
+		return ret, nil
+	}
+	return nil, fmt.Errorf("unsupported call to %v()", ident.Name)
+}
+
+// comprehension evaluates list comprehensions with a single for clause over a list of strings, e.g.
+// [name + ".go" for name in NAMES]
+func (ev *evaluator) comprehension(expr *build.Comprehension, pos int, locals map[string]string) (interface{}, error) {
+	if expr.Curly || len(expr.Clauses) != 1 {
+		return nil, fmt.Errorf("unsupported comprehension %v", build.FormatString(expr))
+	}
+	clause, ok := expr.Clauses[0].(*build.ForClause)
+	if !ok {
+		return nil, fmt.Errorf("unsupported comprehension %v", build.FormatString(expr))
+	}
+	ident, ok := clause.Vars.(*build.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported comprehension %v", build.FormatString(expr))
+	}
+
+	val, err := ev.eval(clause.X, pos, locals)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := val.([]string)
+	if !ok {
+		return nil, fmt.Errorf("can only iterate over lists, got %v", build.FormatString(clause.X))
+	}
+
+	inner := make(map[string]string, len(locals)+1)
+	for k, v := range locals {
+		inner[k] = v
+	}
+	ret := make([]string, 0, len(items))
+	for _, item := range items {
+		inner[ident.Name] = item
+		val, err := ev.eval(expr.Body, pos, inner)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("comprehensions must produce strings, got %v", build.FormatString(expr.Body))
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+// add adds two values, concatenating strings or lists
+func add(x, y interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case string:
+		if y, ok := y.(string); ok {
+			return x + y, nil
+		}
+	case []string:
+		if y, ok := y.([]string); ok {
+			ret := make([]string, 0, len(x)+len(y))
+			return append(append(ret, x...), y...), nil
+		}
+	}
+	return nil, fmt.Errorf("can't add %T and %T", x, y)
+}
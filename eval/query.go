@@ -0,0 +1,92 @@
+package eval
+
+import (
+	"strings"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/please"
+)
+
+// QuerySources asks plz for the srcs of all the targets in the package in a directory with a single invocation. Once
+// queried, EvalGlobs uses these rather than evaluating the srcs itself, so they're exactly what Please would use, even
+// for macros and other expressions puku doesn't understand.
+func (e *Eval) QuerySources(plzPath, dir string) error {
+	if _, ok := e.srcs[dir]; ok {
+		return nil
+	}
+
+	pkg := dir
+	if pkg == "." {
+		pkg = ""
+	}
+	res, err := please.QuerySources(plzPath, "//"+pkg+":all")
+	if err != nil {
+		return err
+	}
+
+	srcs := make(map[string][]string, len(res))
+	for target, targetSrcs := range res {
+		l := labels.Parse(target)
+		if l.Package != pkg {
+			continue
+		}
+		// Files are relative to the repo root, but puku deals with them relative to their package
+		ret := make([]string, 0, len(targetSrcs))
+		for _, src := range targetSrcs {
+			if pkg != "" && !LookLikeBuildLabel(src) {
+				src = strings.TrimPrefix(src, pkg+"/")
+			}
+			ret = append(ret, src)
+		}
+		srcs[l.Target] = ret
+	}
+	e.srcs[dir] = srcs
+	return nil
+}
+
+// queriedSources returns the srcs of the rule that we got from plz, if we've queried them. The strings in any lists in
+// the srcs are also included, as these are where puku adds new sources, so they may not be in the build file on disk
+// that plz read.
+func (e *Eval) queriedSources(dir string, rule *build.Rule, attrName string) ([]string, bool) {
+	if attrName != "srcs" {
+		return nil, false
+	}
+	srcs, ok := e.srcs[dir][rule.Name()]
+	if !ok {
+		return nil, false
+	}
+
+	ret := append([]string{}, srcs...)
+	seen := make(map[string]struct{}, len(srcs))
+	for _, src := range srcs {
+		seen[src] = struct{}{}
+	}
+	for _, src := range literalStrings(rule.Attr(attrName)) {
+		if _, ok := seen[src]; !ok {
+			seen[src] = struct{}{}
+			ret = append(ret, src)
+		}
+	}
+	return ret, true
+}
+
+// literalStrings returns the strings in the lists in an expression, or in lists concatenated with +
+func literalStrings(expr build.Expr) []string {
+	switch expr := expr.(type) {
+	case *build.ListExpr:
+		var ret []string
+		for _, e := range expr.List {
+			if s, ok := e.(*build.StringExpr); ok {
+				ret = append(ret, s.Value)
+			}
+		}
+		return ret
+	case *build.BinaryExpr:
+		if expr.Op == "+" {
+			return append(literalStrings(expr.X), literalStrings(expr.Y)...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package eval
+
+import (
+	"path"
+	"strings"
+
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/please"
+)
+
+// goRequirement is what we require targets to provide when they're used as sources of Go rules
+const goRequirement = "go"
+
+// Prefetch queries and builds the targets for the given source labels, using as few plz invocations as possible. The
+// results are cached, so BuildSources doesn't need to run plz for these labels again.
+func (e *Eval) Prefetch(plzPath string, srcLabels []string) error {
+	if err := e.queryTargets(plzPath, srcLabels); err != nil {
+		return err
+	}
+
+	var toBuild []string
+	seen := map[string]struct{}{}
+	for _, l := range srcLabels {
+		for _, target := range e.provided(l) {
+			if _, ok := e.outs[target]; ok {
+				continue
+			}
+			if _, ok := seen[target]; !ok {
+				seen[target] = struct{}{}
+				toBuild = append(toBuild, target)
+			}
+		}
+	}
+	return e.buildTargets(plzPath, toBuild)
+}
+
+// ResetTargets forgets what we've learnt about targets from plz, e.g. because their outputs may have changed
+func (e *Eval) ResetTargets() {
+	e.targets = map[string]*please.TargetInfo{}
+	e.outs = map[string][]string{}
+	e.srcs = map[string]map[string][]string{}
+}
+
+// queryTargets queries the targets, and the targets they provide, with one plz invocation for each level of provides
+func (e *Eval) queryTargets(plzPath string, targets []string) error {
+	pending := e.unqueried(targets)
+	for len(pending) > 0 {
+		infos, err := please.QueryTargets(plzPath, pending...)
+		if err != nil {
+			return err
+		}
+
+		var next []string
+		for _, target := range pending {
+			info, ok := infos[target]
+			if !ok {
+				info = new(please.TargetInfo)
+			}
+			e.targets[target] = info
+			next = append(next, info.Provides[goRequirement]...)
+		}
+		pending = e.unqueried(next)
+	}
+	return nil
+}
+
+// unqueried returns the targets we haven't queried yet, without duplicates
+func (e *Eval) unqueried(targets []string) []string {
+	var ret []string
+	seen := map[string]struct{}{}
+	for _, target := range targets {
+		if _, ok := e.targets[target]; ok {
+			continue
+		}
+		if _, ok := seen[target]; !ok {
+			seen[target] = struct{}{}
+			ret = append(ret, target)
+		}
+	}
+	return ret
+}
+
+// provided returns the targets that a target provides for Go, repeating this if those targets also provide different
+// targets. The targets must have been queried already.
+func (e *Eval) provided(target string) []string {
+	if ret := e.provide(target, map[string]struct{}{}); len(ret) > 0 {
+		return ret
+	}
+	// The provides only lead back to targets we've already visited, so there's nothing better to use
+	return []string{target}
+}
+
+// provide recursively finds the targets provided for a target, skipping any we've already visited so cycles between
+// provides don't recurse forever
+func (e *Eval) provide(target string, visited map[string]struct{}) []string {
+	visited[target] = struct{}{}
+	providedTargets := e.targets[target].Provides[goRequirement]
+	if len(providedTargets) == 0 || (len(providedTargets) == 1 && providedTargets[0] == target) {
+		return []string{target}
+	}
+
+	ret := make([]string, 0, len(providedTargets))
+	for _, providedTarget := range providedTargets {
+		if providedTarget == target {
+			ret = append(ret, providedTarget) // Providing itself, don't recurse
+		} else if _, ok := visited[providedTarget]; !ok {
+			ret = append(ret, e.provide(providedTarget, visited)...)
+		}
+	}
+	return ret
+}
+
+// buildTargets builds the targets with a single plz invocation, working out which of the outputs belong to each target
+// from their outs
+func (e *Eval) buildTargets(plzPath string, targets []string) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	outs, err := please.Build(plzPath, targets...)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 1 {
+		e.outs[targets[0]] = outs
+		return nil
+	}
+
+	for _, target := range targets {
+		targetOuts := matchOuts(target, e.targets[target].Outs, outs)
+		if len(targetOuts) == 0 {
+			// We can't tell which outputs are this target's, so build it on its own. It's already been built, so this
+			// should be quick.
+			if targetOuts, err = please.Build(plzPath, target); err != nil {
+				return err
+			}
+		}
+		e.outs[target] = targetOuts
+	}
+	return nil
+}
+
+// matchOuts returns the paths of the outputs of a target from the output of plz build. Outputs are in plz-out/gen or
+// plz-out/bin, under the target's package.
+func matchOuts(target string, outNames, outs []string) []string {
+	pkg := labels.Parse(target).Package
+
+	var ret []string
+	for _, name := range outNames {
+		for _, dir := range []string{"plz-out/gen", "plz-out/bin"} {
+			p := path.Join(dir, pkg, name)
+			for _, out := range outs {
+				if out == p || strings.HasSuffix(out, "/"+p) {
+					ret = append(ret, out)
+				}
+			}
+		}
+	}
+	return ret
+}
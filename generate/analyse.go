@@ -0,0 +1,164 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/config"
+	"github.com/please-build/puku/edit"
+	"github.com/please-build/puku/graph"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+// resolution is the result of resolving an import for a target
+type resolution struct {
+	dep string
+	err error
+}
+
+// recordResolution records the result of resolving an import for a rule, if we're analysing packages
+func (u *updater) recordResolution(rule *edit.Rule, importPath, dep string, err error) {
+	if u.resolutions == nil {
+		return
+	}
+
+	label := rule.Label()
+	if u.resolutions[label] == nil {
+		u.resolutions[label] = map[string]*resolution{}
+	}
+	if dep != "" {
+		dep = shorten(rule.Dir, dep)
+	}
+	u.resolutions[label][importPath] = &resolution{dep: dep, err: err}
+}
+
+// ImportResolution is the result of resolving one of the imports of a source file
+type ImportResolution struct {
+	*Import
+	// File is the name of the source file the import is from
+	File string
+	// Target is the label of the target the source file belongs to
+	Target string
+	// Dep is the dependency the import resolved to. This is empty if the import doesn't need a dependency, e.g. for
+	// packages in the standard library.
+	Dep string
+	// Err is the error resolving the import, if it couldn't be resolved
+	Err error
+	// Missing is whether the dependency is missing from the target in the build file on disk
+	Missing bool
+}
+
+// Analysis is the result of analysing a package without writing any changes to disk
+type Analysis struct {
+	// Dir is the directory of the package
+	Dir string
+	// Imports are the imports of each of the Go sources in the package that belong to a target
+	Imports []*ImportResolution
+	// BuildFile is the path to the package's build file
+	BuildFile string
+	// NewBuildFile is the content of the build file after puku has updated it, or empty if puku wouldn't change it
+	NewBuildFile string
+}
+
+// Analyse works out how puku would update the package in the given directory, without writing anything to disk. This
+// runs the same pipeline as Update, additionally recording how each of the imports in the package was resolved.
+func Analyse(plzConf *please.Config, opts options.Options, dir string) (*Analysis, error) {
+	// Load the build file as it is on disk before we update it, so we can find out which deps are missing
+	onDisk, err := graph.New(plzConf.BuildFileNames(), opts).LoadFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	u := newUpdater(plzConf, opts)
+	u.resolutions = map[string]map[string]*resolution{}
+	if err := u.update(dir); err != nil {
+		return nil, err
+	}
+
+	conf, err := config.ReadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := ImportDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	file, err := u.graph.LoadFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &Analysis{
+		Dir:       dir,
+		BuildFile: file.Path,
+	}
+
+	rules, _ := u.readRulesFromFile(conf, file, dir)
+	for _, rule := range rules {
+		resolutions, ok := u.resolutions[rule.Label()]
+		if !ok {
+			continue
+		}
+
+		srcs, err := u.eval.EvalGlobs(rule.Dir, rule.Rule, rule.SrcsAttr())
+		if err != nil {
+			return nil, err
+		}
+		existingDeps := existingRuleDeps(onDisk, rule.Name(), dir)
+		for _, src := range srcs {
+			f, ok := sources[src]
+			if !ok {
+				continue
+			}
+			for _, spec := range f.ImportSpecs {
+				res, ok := resolutions[spec.Path]
+				if !ok {
+					continue
+				}
+				_, exists := existingDeps[normaliseLabel(res.dep, dir)]
+				analysis.Imports = append(analysis.Imports, &ImportResolution{
+					Import:  spec,
+					File:    filepath.Join(dir, src),
+					Target:  rule.Label(),
+					Dep:     res.dep,
+					Err:     res.err,
+					Missing: res.dep != "" && !exists,
+				})
+			}
+		}
+	}
+
+	// Like when we write build files, only rewrite the file if puku has made meaningful changes to it
+	if oldContent, err := os.ReadFile(file.Path); err != nil || string(oldContent) != string(build.FormatWithoutRewriting(file)) {
+		analysis.NewBuildFile = string(build.Format(file))
+	}
+	return analysis, nil
+}
+
+// existingRuleDeps returns the deps of the rule with the given name in the build file for the package in dir. The deps
+// are normalised with normaliseLabel, so they can be compared to the deps puku resolves regardless of how they're
+// written.
+func existingRuleDeps(file *build.File, name, dir string) map[string]struct{} {
+	deps := map[string]struct{}{}
+	rule := edit.FindTargetByName(file, name)
+	if rule == nil {
+		return deps
+	}
+	for _, dep := range rule.AttrStrings("deps") {
+		deps[normaliseLabel(dep, dir)] = struct{}{}
+	}
+	return deps
+}
+
+// normaliseLabel returns the canonical form of a label relative to the package in dir, e.g. //foo:foo and :foo in the
+// foo package both become //foo
+func normaliseLabel(label, dir string) string {
+	if dir == "." {
+		dir = ""
+	}
+	return labels.ParseRelative(label, dir).Format()
+}
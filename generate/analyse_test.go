@@ -0,0 +1,36 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistingRuleDeps(t *testing.T) {
+	file, err := build.ParseBuild("foo/BUILD", []byte(`
+go_library(
+    name = "foo",
+    deps = [
+        ":bar",
+        "//baz:baz",
+        "///third_party/go/github.com_example_module//qux",
+    ],
+)
+`))
+	require.NoError(t, err)
+
+	deps := existingRuleDeps(file, "foo", "foo")
+	for _, dep := range []string{"//foo:bar", ":bar", "//baz", "//baz:baz", "///third_party/go/github.com_example_module//qux"} {
+		assert.Contains(t, deps, normaliseLabel(dep, "foo"), "expected %v to be an existing dep", dep)
+	}
+	assert.NotContains(t, deps, normaliseLabel(":baz", "foo"))
+	assert.Empty(t, existingRuleDeps(file, "missing", "foo"))
+}
+
+func TestNormaliseLabel(t *testing.T) {
+	assert.Equal(t, "//foo", normaliseLabel(":foo", "foo"))
+	assert.Equal(t, "//foo", normaliseLabel("//foo:foo", "bar"))
+	assert.Equal(t, "//:bar", normaliseLabel(":bar", "."))
+}
@@ -0,0 +1,161 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/edit"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+// moduleKind is the kind given to third party modules in the dependency graph
+const moduleKind = "module"
+
+// DepGraph is the graph of the Go targets puku updated, and their dependencies. Third party dependencies are collapsed
+// into a node for their module.
+type DepGraph struct {
+	Nodes []*DepGraphNode
+	Edges []*DepGraphEdge
+}
+
+// DepGraphNode is a target, or third party module, in the dependency graph
+type DepGraphNode struct {
+	// Label is the label of the target, or the path of the module for third party modules
+	Label string
+	// Kind is the kind of the target, e.g. go_library, or "module" for third party modules
+	Kind string
+}
+
+// DepGraphEdge is a dependency between two nodes in the dependency graph
+type DepGraphEdge struct {
+	From, To string
+	// Imports are the import paths that caused the dependency. This is empty for dependencies puku adds for other
+	// reasons, e.g. tests depending on the library for their package.
+	Imports []string
+}
+
+// buildDepGraph returns the graph of the targets puku updated, and their dependencies. Third party dependencies are
+// collapsed into a node for their module, and the kinds of targets are looked up from their build files.
+func (u *updater) buildDepGraph() *DepGraph {
+	nodes := map[string]string{}
+	edges := map[string]map[string]*DepGraphEdge{}
+	addEdge := func(from, to string, imports ...string) {
+		if edges[from] == nil {
+			edges[from] = map[string]*DepGraphEdge{}
+		}
+		edge, ok := edges[from][to]
+		if !ok {
+			edge = &DepGraphEdge{From: from, To: to, Imports: []string{}}
+			edges[from][to] = edge
+		}
+		edge.Imports = append(edge.Imports, imports...)
+	}
+
+	for _, target := range u.graph.Targets() {
+		nodes[target] = u.targetKind(target)
+		for _, dep := range u.graph.Dependencies(target) {
+			to := dep.To.Format()
+			if !strings.HasPrefix(to, "///") {
+				nodes[to] = u.targetKind(to)
+				addEdge(target, to, dep.Imports...)
+				continue
+			}
+			for _, i := range dep.Imports {
+				if module := moduleForPackage(u.modules, i); module != "" {
+					nodes[module] = moduleKind
+					addEdge(target, module, i)
+				} else {
+					nodes[to] = ""
+					addEdge(target, to, i)
+				}
+			}
+		}
+	}
+
+	ret := new(DepGraph)
+	for label, kind := range nodes {
+		ret.Nodes = append(ret.Nodes, &DepGraphNode{Label: label, Kind: kind})
+	}
+	sort.Slice(ret.Nodes, func(i, j int) bool {
+		return ret.Nodes[i].Label < ret.Nodes[j].Label
+	})
+
+	for _, edges := range edges {
+		for _, edge := range edges {
+			ret.Edges = append(ret.Edges, edge)
+		}
+	}
+	sort.Slice(ret.Edges, func(i, j int) bool {
+		if ret.Edges[i].From != ret.Edges[j].From {
+			return ret.Edges[i].From < ret.Edges[j].From
+		}
+		return ret.Edges[i].To < ret.Edges[j].To
+	})
+	return ret
+}
+
+// targetKind looks up the kind of a target from its build file, returning an empty string if it can't be found
+func (u *updater) targetKind(label string) string {
+	if strings.HasPrefix(label, "///") {
+		return ""
+	}
+	l := labels.Parse(label)
+	file, err := u.graph.LoadFile(l.Package)
+	if err != nil {
+		return ""
+	}
+	if rule := edit.FindTargetByName(file, l.Target); rule != nil {
+		return rule.Kind()
+	}
+	return ""
+}
+
+// WriteGraph writes the dependency graph of the Go targets in the given paths in the given format, i.e. dot or json.
+// This works out the dependencies the same way as Update, so it may query the module proxy for imports that aren't
+// already in the repo, but the build files and third party modules aren't written.
+func WriteGraph(out io.Writer, format string, plzConf *please.Config, opts options.Options, paths ...string) error {
+	u := newUpdater(plzConf, opts)
+	if err := u.update(paths...); err != nil {
+		return err
+	}
+
+	g := u.buildDepGraph()
+	switch format {
+	case "json":
+		return json.NewEncoder(out).Encode(g)
+	case "dot":
+		return g.writeDot(out)
+	}
+	return fmt.Errorf("unknown graph format %v", format)
+}
+
+// writeDot writes the graph in the graphviz dot format
+func (g *DepGraph) writeDot(out io.Writer) error {
+	sb := new(strings.Builder)
+	sb.WriteString("digraph puku {\n")
+	for _, node := range g.Nodes {
+		label := node.Label
+		if node.Kind != "" {
+			label += "\n" + node.Kind
+		}
+		fmt.Fprintf(sb, "  %v [label=%v];\n", strconv.Quote(node.Label), strconv.Quote(label))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(sb, "  %v -> %v", strconv.Quote(edge.From), strconv.Quote(edge.To))
+		if len(edge.Imports) > 0 {
+			fmt.Fprintf(sb, " [label=%v]", strconv.Quote(strings.Join(edge.Imports, "\n")))
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
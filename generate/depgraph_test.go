@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+func TestDepGraph(t *testing.T) {
+	u := newUpdater(new(please.Config), options.TestOptions)
+	u.modules = []string{"github.com/example/module"}
+
+	bar, err := build.ParseBuild("bar/BUILD", []byte(`go_library(name = "bar")`))
+	require.NoError(t, err)
+	u.graph.SetFile("bar", bar)
+
+	foo, err := build.ParseBuild("foo/BUILD", []byte(`
+go_library(name = "foo")
+
+go_test(name = "foo_test")
+`))
+	require.NoError(t, err)
+	u.graph.SetFile("foo", foo)
+
+	u.graph.AddTarget("//foo:foo")
+	u.graph.AddDependency("//foo:foo", "//bar", "github.com/this/module/bar")
+	u.graph.AddDependency("//foo:foo", "///third_party/go/github.com_example_module//foo", "github.com/example/module/foo")
+	u.graph.AddDependency("//foo:foo", "///third_party/go/github.com_example_module//bar", "github.com/example/module/bar")
+	u.graph.AddTarget("//foo:foo_test")
+	u.graph.AddDependency("//foo:foo_test", ":foo", "")
+
+	g := u.buildDepGraph()
+	assert.Equal(t, []*DepGraphNode{
+		{Label: "//bar", Kind: "go_library"},
+		{Label: "//foo", Kind: "go_library"},
+		{Label: "//foo:foo_test", Kind: "go_test"},
+		{Label: "github.com/example/module", Kind: "module"},
+	}, g.Nodes)
+	assert.Equal(t, []*DepGraphEdge{
+		{From: "//foo", To: "//bar", Imports: []string{"github.com/this/module/bar"}},
+		{From: "//foo", To: "github.com/example/module", Imports: []string{"github.com/example/module/foo", "github.com/example/module/bar"}},
+		{From: "//foo:foo_test", To: "//foo", Imports: []string{}},
+	}, g.Edges)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, g.writeDot(out))
+	assert.Equal(t, `digraph puku {
+  "//bar" [label="//bar\ngo_library"];
+  "//foo" [label="//foo\ngo_library"];
+  "//foo:foo_test" [label="//foo:foo_test\ngo_test"];
+  "github.com/example/module" [label="github.com/example/module\nmodule"];
+  "//foo" -> "//bar" [label="github.com/this/module/bar"];
+  "//foo" -> "github.com/example/module" [label="github.com/example/module/foo\ngithub.com/example/module/bar"];
+  "//foo:foo_test" -> "//foo";
+}
+`, out.String())
+}
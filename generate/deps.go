@@ -73,9 +73,8 @@ func (u *updater) reallyResolveImport(conf *config.Config, i string) (string, er
 
 	log.Infof("Resolving module for %v...", i)
 
-	// Otherwise try and resolve it to a new dep via the module proxy. We assume the module will contain the package.
-	// Please will error out in a reasonable way if it doesn't.
-	// TODO it would be more correct to download the module and check it actually contains the package
+	// Otherwise try and resolve it to a new dep via the module proxy. This checks the module actually contains the
+	// package, so we don't add a module that only shares a prefix with the import path.
 	mod, err := u.proxy.ResolveModuleForPackage(i)
 	if err != nil {
 		return "", err
@@ -146,6 +145,13 @@ func (u *updater) localDep(importPath string) (string, error) {
 	// If we can't find the lib target, and the target package is in scope for us to potentially generate it, check if
 	// we are going to generate it.
 	if len(libTargets) != 0 {
+		// If the directory has a library per package, prefer the one named after the directory, as that's the package
+		// the import path most likely refers to.
+		for _, rule := range libTargets {
+			if rule.Name() == filepath.Base(path) {
+				return edit.BuildTarget(rule.Name(), path, ""), nil
+			}
+		}
 		return edit.BuildTarget(libTargets[0].Name(), path, ""), nil
 	}
 
@@ -162,12 +168,30 @@ func (u *updater) localDep(importPath string) (string, error) {
 	}
 
 	// If there are any non-test sources, then we will generate a go_library here later on. Return that target name.
+	pkgName := ""
 	for _, f := range files {
-		if !f.IsTest() {
-			return edit.BuildTarget(filepath.Base(importPath), path, ""), nil
+		if f.IsTest() {
+			continue
+		}
+		// Prefer the package named after the import path. Otherwise, pick the first name alphabetically, so we don't
+		// depend on the order we iterate over the files in.
+		if f.Name == filepath.Base(importPath) {
+			pkgName = f.Name
+			break
 		}
+		if pkgName == "" || f.Name < pkgName {
+			pkgName = f.Name
+		}
+	}
+	if pkgName == "" {
+		return "", nil
+	}
+
+	name, err := conf.TargetName(kinds.Lib, path, pkgName)
+	if err != nil {
+		return "", err
 	}
-	return "", nil
+	return edit.BuildTarget(name, path, ""), nil
 }
 
 func depTarget(modules []string, importPath, thirdPartyFolder string) string {
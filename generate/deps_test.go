@@ -1,6 +1,7 @@
 package generate
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/please-build/puku/config"
 	"github.com/please-build/puku/options"
 	"github.com/please-build/puku/please"
-	"github.com/please-build/puku/proxy"
 	"github.com/please-build/puku/trie"
 )
 
@@ -50,6 +50,50 @@ func TestLocalDeps(t *testing.T) {
 	assert.Equal(t, "//test_project/foo:bar", trgt)
 }
 
+func TestLocalDepWithMultiplePackages(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	require.NoError(t, os.Mkdir("multi", 0755))
+	require.NoError(t, os.WriteFile("multi/puku.json", []byte(`{"nameTemplates": {"lib": "{{.Package}}_lib"}}`), 0644))
+	files := map[string]string{
+		"a.go":      "package aaa\n",
+		"b.go":      "package multi\n",
+		"c.go":      "package zzz\n",
+		"b_test.go": "package multi_test\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join("multi", name), []byte(content), 0644))
+	}
+
+	conf := new(please.Config)
+	conf.Parse.BuildFileName = []string{"BUILD"}
+	conf.Plugin.Go.ImportPath = []string{"github.com/some/module"}
+
+	u := newUpdater(conf, options.TestOptions)
+	u.paths = []string{"github.com/some/module/multi"}
+
+	t.Run("prefers the package named after the import path", func(t *testing.T) {
+		trgt, err := u.localDep("github.com/some/module/multi")
+		require.NoError(t, err)
+		assert.Equal(t, "//multi:multi_lib", trgt)
+	})
+
+	require.NoError(t, os.Remove("multi/b.go"))
+
+	t.Run("otherwise picks the first package alphabetically", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			trgt, err := u.localDep("github.com/some/module/multi")
+			require.NoError(t, err)
+			assert.Equal(t, "//multi:aaa_lib", trgt)
+		}
+	})
+}
+
 func TestResolveImport(t *testing.T) {
 	installs := trie.New()
 	installs.Add("installed", "//third_party/go:installed")
@@ -67,7 +111,9 @@ func TestResolveImport(t *testing.T) {
 			"resolved": "//third_party/go:resolved",
 		},
 		modules: []string{"github.com/cached-module"},
-		proxy:   proxy.New(proxy.DefaultURL),
+		proxy: FakeProxy{modules: map[string]string{
+			"github.com/please-build/puku/package": "github.com/please-build/puku",
+		}},
 	}
 
 	t.Run("resolve against a module in the puku.json", func(t *testing.T) {
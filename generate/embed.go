@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/please-build/puku/edit"
+	"github.com/please-build/puku/eval"
+)
+
+// embedDirective is the comment that embeds files into a Go package
+const embedDirective = "//go:embed"
+
+// embedPatterns returns the patterns from any //go:embed directives in the file
+func embedPatterns(f *ast.File) []string {
+	var patterns []string
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			args, ok := strings.CutPrefix(c.Text, embedDirective)
+			if !ok || (args != "" && !unicode.IsSpace(rune(args[0]))) {
+				continue
+			}
+			patterns = append(patterns, splitEmbedPatterns(args)...)
+		}
+	}
+	return patterns
+}
+
+// splitEmbedPatterns splits the arguments to a //go:embed directive. Like the go tool, patterns are separated by spaces,
+// and can be quoted with double quotes or back quotes if they contain spaces.
+func splitEmbedPatterns(args string) []string {
+	var patterns []string
+	for {
+		args = strings.TrimLeftFunc(args, unicode.IsSpace)
+		if args == "" {
+			return patterns
+		}
+
+		var pattern string
+		switch args[0] {
+		case '"', '`':
+			quoted, err := strconv.QuotedPrefix(args)
+			if err != nil {
+				// The directive is malformed. The go tool will complain about this so there's no need for us to.
+				return patterns
+			}
+			pattern, _ = strconv.Unquote(quoted)
+			args = args[len(quoted):]
+		default:
+			end := strings.IndexFunc(args, unicode.IsSpace)
+			if end < 0 {
+				end = len(args)
+			}
+			pattern, args = args[:end], args[end:]
+		}
+		patterns = append(patterns, pattern)
+	}
+}
+
+// expandEmbedPatterns returns the files and directories in the package directory that match the patterns, relative to
+// that directory. Directories are added as they are with the all: prefix. Otherwise, like the go tool, they're expanded
+// to the files in them, skipping those starting with . or _, which the go tool only embeds from directories with all:.
+func expandEmbedPatterns(dir string, patterns []string) ([]string, error) {
+	var srcs []string
+	done := map[string]struct{}{}
+	for _, pattern := range patterns {
+		pattern, all := strings.CutPrefix(pattern, "all:")
+
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid //go:embed pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			// This is most likely a file generated by another rule, which would be added to the rule by label, so
+			// there's nothing for us to do.
+			log.Debugf("//go:embed pattern %q doesn't match any files in %v", pattern, dir)
+			continue
+		}
+
+		for _, match := range matches {
+			files := []string{match}
+			if info, err := os.Stat(match); err == nil && info.IsDir() && !all {
+				if files, err = embeddedDirFiles(match); err != nil {
+					return nil, err
+				}
+			}
+			for _, file := range files {
+				src, err := filepath.Rel(dir, file)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := done[src]; ok {
+					continue
+				}
+				done[src] = struct{}{}
+				srcs = append(srcs, src)
+			}
+		}
+	}
+	return srcs, nil
+}
+
+// embeddedDirFiles returns the files the go tool embeds from a directory without the all: prefix
+func embeddedDirFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && isIgnoredEmbedName(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// isIgnoredEmbedName returns whether the go tool ignores the file when embedding a directory without the all: prefix
+func isIgnoredEmbedName(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// updateEmbedSrcs makes sure the files embedded by the rule's sources are in its embed attribute, removing any that
+// aren't embedded anymore. Build labels in the attribute are left alone, as they're not managed by puku.
+func updateEmbedSrcs(rule *edit.Rule, patterns []string) error {
+	srcs, err := expandEmbedPatterns(rule.Dir, patterns)
+	if err != nil {
+		return err
+	}
+
+	rule.SetManagedValues(rule.EmbedAttr(), srcs, func(v string) bool {
+		return !eval.LookLikeBuildLabel(v)
+	})
+	return nil
+}
@@ -0,0 +1,103 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/edit"
+	"github.com/please-build/puku/kinds"
+)
+
+func TestImportEmbedPatterns(t *testing.T) {
+	dir := t.TempDir()
+	src := "package foo\n" +
+		"\n" +
+		"import \"embed\"\n" +
+		"\n" +
+		"//go:embed templates/*.tmpl schema.sql\n" +
+		"var files embed.FS\n" +
+		"\n" +
+		"//go:embed \"with space.txt\" `back quoted.txt`\n" +
+		"var more embed.FS\n" +
+		"\n" +
+		"//go:embedded not.txt\n" +
+		"var notEmbedded string\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.go"), []byte(src), 0644))
+
+	f, err := importFile(dir, "foo.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"embed"}, f.Imports)
+	assert.Equal(t, []string{"templates/*.tmpl", "schema.sql", "with space.txt", "back quoted.txt"}, f.EmbedPatterns)
+}
+
+func TestUpdateEmbedSrcs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0755))
+	for _, file := range []string{"templates/a.tmpl", "templates/b.tmpl", "templates/c.txt", "schema.sql"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0644))
+	}
+
+	newRule := func() *edit.Rule {
+		return edit.NewRule(edit.NewRuleExpr("go_library", "foo"), kinds.DefaultKinds["go_library"], dir)
+	}
+
+	t.Run("adds matching files and directories", func(t *testing.T) {
+		rule := newRule()
+		require.NoError(t, updateEmbedSrcs(rule, []string{"templates/*.tmpl", "schema.sql", "all:templates"}))
+		assert.Equal(t, []string{"templates/a.tmpl", "templates/b.tmpl", "schema.sql", "templates"}, rule.AttrStrings("embed_srcs"))
+	})
+
+	t.Run("removes stale files but keeps build labels", func(t *testing.T) {
+		rule := newRule()
+		rule.SetAttr("embed_srcs", edit.NewStringList([]string{":generated", "old.sql", "schema.sql"}))
+
+		require.NoError(t, updateEmbedSrcs(rule, []string{"schema.sql"}))
+		assert.Equal(t, []string{":generated", "schema.sql"}, rule.AttrStrings("embed_srcs"))
+	})
+
+	t.Run("removes the attribute when nothing is embedded", func(t *testing.T) {
+		rule := newRule()
+		rule.SetAttr("embed_srcs", edit.NewStringList([]string{"old.sql"}))
+
+		require.NoError(t, updateEmbedSrcs(rule, nil))
+		assert.Nil(t, rule.Attr("embed_srcs"))
+	})
+
+	t.Run("skips patterns that don't match any files", func(t *testing.T) {
+		rule := newRule()
+		rule.SetAttr("embed_srcs", edit.NewStringList([]string{":generated", "schema.sql"}))
+
+		require.NoError(t, updateEmbedSrcs(rule, []string{"generated.json", "schema.sql"}))
+		assert.Equal(t, []string{":generated", "schema.sql"}, rule.AttrStrings("embed_srcs"))
+	})
+
+	t.Run("errors when a pattern is invalid", func(t *testing.T) {
+		rule := newRule()
+		assert.Error(t, updateEmbedSrcs(rule, []string{"templates/[*"}))
+	})
+
+	t.Run("only includes hidden files in directories with the all: prefix", func(t *testing.T) {
+		for _, file := range []string{"static/index.html", "static/.hidden.html", "static/_partial.html", "static/css/main.css", "static/_drafts/draft.html"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0644))
+		}
+
+		// Files matched directly by a wildcard are embedded, even if they're hidden
+		rule := newRule()
+		require.NoError(t, updateEmbedSrcs(rule, []string{"static/*.html"}))
+		assert.Equal(t, []string{"static/.hidden.html", "static/_partial.html", "static/index.html"}, rule.AttrStrings("embed_srcs"))
+
+		rule = newRule()
+		require.NoError(t, updateEmbedSrcs(rule, []string{"static"}))
+		assert.Equal(t, []string{"static/css/main.css", "static/index.html"}, rule.AttrStrings("embed_srcs"))
+
+		rule = newRule()
+		require.NoError(t, updateEmbedSrcs(rule, []string{"all:static"}))
+		assert.Equal(t, []string{"static"}, rule.AttrStrings("embed_srcs"))
+	})
+}
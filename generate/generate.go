@@ -2,9 +2,12 @@ package generate
 
 import (
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/please-build/buildtools/build"
@@ -37,7 +40,9 @@ type updater struct {
 
 	graph *graph.Graph
 
-	newModules      []*proxy.Module
+	newModules []*proxy.Module
+	// modulesLoaded is whether the third party modules have been read into modules and installs
+	modulesLoaded   bool
 	modules         []string
 	resolvedImports map[string]string
 	installs        *trie.Trie
@@ -47,6 +52,12 @@ type updater struct {
 
 	proxy    Proxy
 	licences *licences.Licenses
+
+	// issues are the problems found while updating that puku can't fix itself
+	issues []*Issue
+	// resolutions records how the imports for each target were resolved, keyed by the target's label then import path.
+	// This is only populated when analysing packages.
+	resolutions map[string]map[string]*resolution
 }
 
 func newUpdaterWithGraph(g *graph.Graph, conf *please.Config) *updater {
@@ -58,7 +69,7 @@ func newUpdaterWithGraph(g *graph.Graph, conf *please.Config) *updater {
 		plzConf:         conf,
 		graph:           g,
 		installs:        trie.New(),
-		eval:            eval.New(glob.New()),
+		eval:            eval.New(glob.New(conf.BuildFileNames())).WithFileLoader(g.LoadFile),
 		resolvedImports: map[string]string{},
 	}
 }
@@ -76,15 +87,35 @@ func Update(plzConf *please.Config, opts options.Options, paths ...string) error
 	if err := u.update(paths...); err != nil {
 		return err
 	}
+	for _, issue := range u.issues {
+		log.Warningf("%v", issue)
+	}
 	return u.graph.FormatFiles()
 }
 
+// UpdateToStdout writes the updated build files to stdout, followed by any issues that puku found. Returns
+// ErrIssuesFound if there were any issues.
 func UpdateToStdout(format string, plzConf *please.Config, opts options.Options, paths ...string) error {
 	u := newUpdater(plzConf, opts)
 	if err := u.update(paths...); err != nil {
 		return err
 	}
-	return u.graph.FormatFilesWithWriter(os.Stdout, format)
+	return u.writeUpdates(os.Stdout, format)
+}
+
+// writeUpdates writes the updated build files to out, followed by any issues that puku found. Returns ErrIssuesFound
+// if there were any issues.
+func (u *updater) writeUpdates(out io.Writer, format string) error {
+	if err := u.graph.FormatFilesWithWriter(out, format); err != nil {
+		return err
+	}
+	if len(u.issues) == 0 {
+		return nil
+	}
+	if err := writeIssues(out, format, u.issues); err != nil {
+		return err
+	}
+	return ErrIssuesFound
 }
 
 func (u *updater) readAllModules(conf *config.Config) error {
@@ -150,8 +181,16 @@ func (u *updater) update(paths ...string) error {
 	}
 	u.paths = paths
 
-	if err := u.readAllModules(conf); err != nil {
-		return fmt.Errorf("failed to read third party rules: %v", err)
+	if !u.modulesLoaded {
+		if err := u.readAllModules(conf); err != nil {
+			return fmt.Errorf("failed to read third party rules: %v", err)
+		}
+		u.modulesLoaded = true
+	}
+
+	// Build the sources that come from other targets up front, so we don't have to run plz for each rule
+	if err := u.prefetchSources(paths); err != nil {
+		return err
 	}
 
 	for _, path := range u.paths {
@@ -169,6 +208,10 @@ func (u *updater) update(paths ...string) error {
 		}
 	}
 
+	for _, cycle := range u.graph.FindCycles() {
+		u.addIssue(cycle[0].From.Format(), nil, "import cycle: "+graph.FormatCycle(cycle))
+	}
+
 	// Save any new modules we needed back to the third party file
 	return u.addNewModules(conf)
 }
@@ -246,6 +289,56 @@ func (u *updater) addNewModules(conf *config.Config) error {
 	return nil
 }
 
+// prefetchSources queries and builds the targets used as sources by the rules in the given paths all at once. For
+// packages configured to query their sources from plz, the sources are queried first.
+func (u *updater) prefetchSources(paths []string) error {
+	// The path to plz is configurable, so we might need to run different versions of it
+	srcLabels := map[string][]string{}
+	for _, path := range paths {
+		conf, err := config.ReadConfig(path)
+		if err != nil {
+			return err
+		}
+		if conf.GetStop() {
+			continue
+		}
+		file, err := u.graph.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		// There's nothing to ask plz about if the package doesn't have a build file yet, or any rules in it
+		if conf.ShouldQuerySources() && len(file.Rules("")) > 0 {
+			if err := u.eval.QuerySources(conf.GetPlzPath(), path); err != nil {
+				log.Warningf("failed to query the sources of the targets in %v, so puku will evaluate them itself: %v", path, err)
+			}
+		}
+
+		rules, _ := u.readRulesFromFile(conf, file, path)
+		for _, rule := range rules {
+			if rule.Kind.NonGoSources {
+				continue
+			}
+			srcs, err := u.eval.EvalGlobs(rule.Dir, rule.Rule, rule.SrcsAttr())
+			if err != nil {
+				return err
+			}
+			for _, src := range srcs {
+				if eval.LookLikeBuildLabel(src) {
+					plzPath := conf.GetPlzPath()
+					srcLabels[plzPath] = append(srcLabels[plzPath], labels.ParseRelative(src, rule.Dir).Format())
+				}
+			}
+		}
+	}
+
+	for plzPath, ls := range srcLabels {
+		if err := u.eval.Prefetch(plzPath, ls); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // allSources calculates the sources for a target. It will evaluate the source list resolving globs, and building any
 // srcs that are other build targets.
 //
@@ -268,8 +361,13 @@ func (u *updater) allSources(conf *config.Config, r *edit.Rule, sourceMap map[st
 			continue
 		}
 
-		// These are generated sources in plz-out/gen
-		f, err := importFile(".", src)
+		// These are either generated sources in plz-out/gen, or sources that ImportDir doesn't pick up, e.g. ones in
+		// subdirectories that were matched by a recursive glob, or symlinks
+		dir := r.Dir
+		if strings.HasPrefix(src, "plz-out/") {
+			dir = "."
+		}
+		f, err := importFile(dir, src)
 		if err != nil {
 			continue
 		}
@@ -278,6 +376,40 @@ func (u *updater) allSources(conf *config.Config, r *edit.Rule, sourceMap map[st
 	return srcs, sources, nil
 }
 
+// applicableDependencyRules returns the dependency rules that apply to the target with the given label
+func applicableDependencyRules(conf *config.Config, label string) []*config.DependencyRule {
+	var ret []*config.DependencyRule
+	for _, rule := range conf.GetDependencyRules() {
+		if rule.AppliesTo(label) {
+			ret = append(ret, rule)
+		}
+	}
+	return ret
+}
+
+// setNewRuleAttrs sets the attributes configured for new rules of the rule's kind. These are only set when the rule is
+// created, so any changes made to them later on are left alone.
+func setNewRuleAttrs(rule *edit.Rule, attrs map[string]interface{}) error {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		// The name is generated by puku and the sources are allocated to the rule as usual
+		if name == "name" || name == rule.SrcsAttr() {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := edit.NewValueExpr(attrs[name])
+		if err != nil {
+			return fmt.Errorf("invalid value for %v in newRuleAttrs for %v: %w", name, rule.Kind.Name, err)
+		}
+		rule.SetAttr(name, value)
+	}
+	return nil
+}
+
 func setExternal(rule *edit.Rule) {
 	rule.SetAttr("external", &build.Ident{Name: "True"})
 }
@@ -317,50 +449,88 @@ func (u *updater) updateRuleDeps(conf *config.Config, rule *edit.Rule, rules []*
 
 	label := edit.BuildTarget(rule.Name(), rule.Dir, "")
 
+	u.graph.AddTarget(label)
+
+	importPath := path.Join(u.plzConf.ImportPath(), rule.Dir)
+	depRules := applicableDependencyRules(conf, label)
+
 	deps := map[string]struct{}{}
+	// Deps for imports that aren't allowed. We still add these so the build fails, but we don't want to update their
+	// visibility to allow them.
+	disallowedDeps := map[string]struct{}{}
+	var fuzzTests, embedPatterns []string
 	for _, src := range srcs {
 		f := targetFiles[src]
 		if f == nil {
 			rule.RemoveSrc(src) // The src doesn't exist so remove it from the list of srcs
+			u.eval.RemoveQueriedSource(rule.Dir, rule.Name(), src)
 			continue
 		}
+		fuzzTests = append(fuzzTests, f.FuzzTests...)
+		embedPatterns = append(embedPatterns, f.EmbedPatterns...)
 		for _, i := range f.Imports {
 			if _, ok := done[i]; ok {
 				continue
 			}
 			done[i] = struct{}{}
 
-			// If the dep is provided by the kind (i.e. the build def adds it) then skip this import
+			// Denied dependencies are still added, so the build fails, but we don't widen the visibility of the target
+			// to allow them
+			denied := false
+			for _, depRule := range depRules {
+				if depRule.DeniesImport(i) {
+					u.addIssue(label, f.importSpec(i), fmt.Sprintf("can't import %q: denied by dependency rule %q", i, depRule.Name))
+					denied = true
+				}
+			}
 
-			dep, err := u.resolveImport(conf, i)
+			dep, err := u.resolveImportForRule(conf, rule, f, i)
+			u.recordResolution(rule, i, dep, err)
 			if err != nil {
-				log.Warningf("couldn't resolve %q for %v: %v", i, rule.Label(), err)
+				if spec := f.importSpec(i); spec != nil {
+					log.Warningf("%v: couldn't resolve %q for %v: %v", spec.Pos, i, rule.Label(), err)
+				} else {
+					log.Warningf("couldn't resolve %q for %v: %v", i, rule.Label(), err)
+				}
 				continue
 			}
 			if dep == "" {
 				continue
 			}
-			if rule.Kind.IsProvided(dep) {
-				continue
+
+			for _, depRule := range depRules {
+				if depRule.DeniesDep(dep) {
+					u.addIssue(label, f.importSpec(i), fmt.Sprintf("can't depend on %v for %q: denied by dependency rule %q", dep, i, depRule.Name))
+					denied = true
+				}
 			}
+			u.graph.AddDependency(label, dep, i)
 
 			dep = shorten(rule.Dir, dep)
 
 			if _, ok := deps[dep]; !ok {
 				deps[dep] = struct{}{}
 			}
+
+			if isInternalViolation(importPath, i) {
+				u.addIssue(label, f.importSpec(i), fmt.Sprintf("can't import internal package %q from %v", i, importPath))
+				denied = true
+			}
+			if denied {
+				disallowedDeps[dep] = struct{}{}
+			}
 		}
 	}
 
 	// Add any libraries for the same package as us
-	if rule.Kind.Type == kinds.Test && !isExternal(rule) {
+	if rule.IsTest() && !isExternal(rule) {
 		pkgName, err := u.rulePkg(conf, packageFiles, rule)
 		if err != nil {
 			return err
 		}
 
 		for _, libRule := range rules {
-			if libRule.Kind.Type == kinds.Test {
+			if libRule.IsTest() {
 				continue
 			}
 			libPkgName, err := u.rulePkg(conf, packageFiles, libRule)
@@ -376,12 +546,29 @@ func (u *updater) updateRuleDeps(conf *config.Config, rule *edit.Rule, rules []*
 			if _, ok := deps[t]; !ok {
 				deps[t] = struct{}{}
 			}
+			u.graph.AddDependency(label, t, "")
 		}
 	}
 
+	if rule.EmbedAttr() != "" {
+		if err := updateEmbedSrcs(rule, embedPatterns); err != nil {
+			log.Warningf("couldn't update embedded files for %v: %v", rule.Label(), err)
+		}
+	}
+
+	if rule.Kind.TestData && rule.DataAttr() != "" {
+		updateTestData(rule)
+	}
+
+	if rule.Kind.Type == kinds.Fuzz && rule.DataAttr() != "" {
+		updateFuzzCorpus(rule, fuzzTests)
+	}
+
 	depSlice := make([]string, 0, len(deps))
 	for dep := range deps {
-		u.graph.EnsureVisibility(label, dep)
+		if _, ok := disallowedDeps[dep]; !ok {
+			u.graph.EnsureVisibility(label, dep)
+		}
 		depSlice = append(depSlice, dep)
 	}
 
@@ -390,6 +577,71 @@ func (u *updater) updateRuleDeps(conf *config.Config, rule *edit.Rule, rules []*
 	return nil
 }
 
+// updateTestData makes sure the testdata directory is in the rule's data if it exists, removing it once it's gone
+func updateTestData(rule *edit.Rule) {
+	var data []string
+	if info, err := os.Stat(filepath.Join(rule.Dir, testDataDir)); err == nil && info.IsDir() {
+		data = append(data, testDataDir)
+	}
+
+	rule.SetManagedValues(rule.DataAttr(), data, func(v string) bool {
+		return v == testDataDir
+	})
+}
+
+// updateFuzzCorpus makes sure the seed corpus directory for each of the fuzz tests in the rule (i.e.
+// testdata/fuzz/FuzzXxx) is in its data, removing any that no longer exist.
+func updateFuzzCorpus(rule *edit.Rule, fuzzTests []string) {
+	corpusDir := filepath.Join(testDataDir, "fuzz")
+
+	var corpus []string
+	for _, name := range fuzzTests {
+		dir := filepath.Join(corpusDir, name)
+		if info, err := os.Stat(filepath.Join(rule.Dir, dir)); err == nil && info.IsDir() {
+			corpus = append(corpus, dir)
+		}
+	}
+
+	// Only manage the entries puku would have added, i.e. ones named after a fuzz test, so we leave any other data the
+	// user has added under the corpus directory alone.
+	rule.SetManagedValues(rule.DataAttr(), corpus, func(v string) bool {
+		return filepath.Dir(v) == corpusDir && isTestFunc(filepath.Base(v), "Fuzz")
+	})
+}
+
+// resolveImportForRule resolves an import from one of the rule's sources to the dependency that should be added to the
+// rule, applying any import rules configured for the rule's kind. Returns an empty string if no dependency should be
+// added.
+func (u *updater) resolveImportForRule(conf *config.Config, rule *edit.Rule, f *GoFile, i string) (string, error) {
+	var importRule *kinds.ImportRule
+	if spec := f.importSpec(i); spec != nil {
+		importRule = rule.Kind.ImportRule(i, spec.Name)
+	}
+
+	if importRule == nil {
+		dep, err := u.resolveImport(conf, i)
+		if err != nil {
+			return "", err
+		}
+		// If the dep is provided by the kind (i.e. the build def adds it) then skip this import
+		if rule.Kind.IsProvided(dep) {
+			return "", nil
+		}
+		return dep, nil
+	}
+
+	switch importRule.Action {
+	case kinds.IgnoreImport:
+		return "", nil
+	case kinds.RedirectImport:
+		return importRule.Target, nil
+	case kinds.ForceImport:
+		return u.resolveImport(conf, i)
+	default:
+		return "", fmt.Errorf("unknown import rule action %q", importRule.Action)
+	}
+}
+
 // shorten will shorten lables to the local package
 func shorten(pkg, label string) string {
 	if strings.HasPrefix(label, "///") || strings.HasPrefix(label, "@") {
@@ -439,43 +691,48 @@ func (u *updater) allocateSources(conf *config.Config, pkgDir string, sources ma
 		return nil, err
 	}
 
+	pkgs := libPackageNames(sources)
+	if len(pkgs) > 1 {
+		if conf.ShouldErrorOnMultiplePackages() {
+			return nil, fmt.Errorf("%v contains multiple packages: %v", pkgDir, strings.Join(pkgs, ", "))
+		}
+		// Only warn when there are sources left to allocate, so we don't keep warning about directories that have
+		// already been set up with a library per package.
+		if len(libPackageNames(unallocatedFiles(sources, unallocated))) > 0 {
+			log.Warningf("%v contains multiple packages: %v. Generating a library for each of them.", pkgDir, strings.Join(pkgs, ", "))
+		}
+
+		// Allocate the package named after the directory first, so it gets the existing rules that we can't otherwise
+		// determine the package of
+		sort.SliceStable(unallocated, func(i, j int) bool {
+			return isDirPackage(pkgDir, sources[unallocated[i]].Name) && !isDirPackage(pkgDir, sources[unallocated[j]].Name)
+		})
+	}
+
 	var newRules []*edit.Rule
 	for _, src := range unallocated {
 		importedFile := sources[src]
 		if importedFile == nil {
 			continue // Something went wrong and we haven't imported the file don't try to allocate it
 		}
-		var rule *edit.Rule
-		for _, r := range append(rules, newRules...) {
-			if r.Kind.Type != importedFile.kindType() {
-				continue
-			}
-
-			rulePkgName, err := u.rulePkg(conf, sources, r)
-			if err != nil {
-				return nil, fmt.Errorf("failed to determine package name for //%v:%v: %w", pkgDir, r.Name(), err)
-			}
-
-			// Find a rule that's for the same package and of the same kind (i.e. bin, lib, test)
-			// NB: we return when we find the first one so if there are multiple options, we will pick one essentially at
-			//     random.
-			if rulePkgName == "" || rulePkgName == importedFile.Name {
-				rule = r
-				break
-			}
+		kindType := importedFile.kindType(conf)
+		rule, err := u.findRuleForSource(conf, pkgDir, sources, append(rules, newRules...), importedFile, kindType)
+		if err != nil {
+			return nil, err
 		}
 		if rule == nil {
-			name := filepath.Base(pkgDir)
-			kind := "go_library"
-			if importedFile.IsTest() {
-				name += "_test"
-				kind = "go_test"
+			kind, err := conf.GetDefaultKind(kindType)
+			if err != nil {
+				return nil, err
+			}
+			name, err := newRuleName(conf, kindType, pkgDir, importedFile.Name, len(pkgs) > 1, append(rules, newRules...))
+			if err != nil {
+				return nil, err
 			}
-			if importedFile.IsCmd() {
-				kind = "go_binary"
-				name = "main"
+			rule = edit.NewRule(edit.NewRuleExpr(kind.Name, name), kind, pkgDir)
+			if err := setNewRuleAttrs(rule, conf.GetNewRuleAttrs(kind.Name)); err != nil {
+				return nil, err
 			}
-			rule = edit.NewRule(edit.NewRuleExpr(kind, name), kinds.DefaultKinds[kind], pkgDir)
 			if importedFile.IsExternal(filepath.Join(u.plzConf.ImportPath(), pkgDir)) {
 				setExternal(rule)
 			}
@@ -487,6 +744,102 @@ func (u *updater) allocateSources(conf *config.Config, pkgDir string, sources ma
 	return newRules, nil
 }
 
+// newRuleName returns the name for a new rule for the given package. When the directory contains more than one package,
+// the name from the template would clash between the packages, so the package name is used in place of the directory
+// name for any package not named after the directory. If the template doesn't use either, the name is prefixed with the
+// package name instead.
+func newRuleName(conf *config.Config, kindType kinds.Type, pkgDir, pkgName string, multiplePkgs bool, rules []*edit.Rule) (string, error) {
+	name, err := conf.TargetName(kindType, pkgDir, pkgName)
+	if err != nil || !multiplePkgs {
+		return name, err
+	}
+	if isDirPackage(pkgDir, pkgName) && !hasRuleNamed(rules, name) {
+		return name, nil
+	}
+
+	name, err = conf.TargetName(kindType, pkgName, pkgName)
+	if err != nil {
+		return "", err
+	}
+	if other, err := conf.TargetName(kindType, pkgName+"_", pkgName+"_"); err == nil && other == name {
+		name = pkgName + "_" + name
+	}
+	if hasRuleNamed(rules, name) {
+		return "", fmt.Errorf("can't generate a target for package %v in %v: there's already a target called %v", pkgName, pkgDir, name)
+	}
+	return name, nil
+}
+
+// isDirPackage returns whether the package is named after the directory it's in
+func isDirPackage(pkgDir, pkgName string) bool {
+	return strings.TrimSuffix(pkgName, "_test") == filepath.Base(pkgDir)
+}
+
+func hasRuleNamed(rules []*edit.Rule, name string) bool {
+	for _, r := range rules {
+		if r.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// libPackageNames returns the names of the packages of the non-test sources in a directory. Binaries are generated
+// separately, so the main package isn't included.
+func libPackageNames(sources map[string]*GoFile) []string {
+	names := map[string]struct{}{}
+	for _, f := range sources {
+		if f.IsTest() || f.IsCmd() {
+			continue
+		}
+		names[f.Name] = struct{}{}
+	}
+
+	ret := make([]string, 0, len(names))
+	for name := range names {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// unallocatedFiles returns the imported files for the unallocated sources
+func unallocatedFiles(sources map[string]*GoFile, unallocated []string) map[string]*GoFile {
+	ret := make(map[string]*GoFile, len(unallocated))
+	for _, src := range unallocated {
+		if f, ok := sources[src]; ok {
+			ret[src] = f
+		}
+	}
+	return ret
+}
+
+// findRuleForSource finds an existing rule of the given kind type that the source can be allocated to, returning nil if
+// there isn't one. Rules for the same package are preferred over rules we can't determine the package of, e.g. because
+// they don't have any sources yet.
+func (u *updater) findRuleForSource(conf *config.Config, pkgDir string, sources map[string]*GoFile, rules []*edit.Rule, importedFile *GoFile, kindType kinds.Type) (*edit.Rule, error) {
+	var unknownPkgRule *edit.Rule
+	for _, r := range rules {
+		if r.Kind.Type != kindType {
+			continue
+		}
+
+		rulePkgName, err := u.rulePkg(conf, sources, r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine package name for //%v:%v: %w", pkgDir, r.Name(), err)
+		}
+
+		// Find a rule that's for the same package and of the same kind (i.e. bin, lib, test)
+		if rulePkgName == importedFile.Name {
+			return r, nil
+		}
+		if rulePkgName == "" && unknownPkgRule == nil {
+			unknownPkgRule = r
+		}
+	}
+	return unknownPkgRule, nil
+}
+
 // rulePkg checks the first source it finds for a rule and returns the name from the "package name" directive at the top
 // of the file
 func (u *updater) rulePkg(conf *config.Config, srcs map[string]*GoFile, rule *edit.Rule) (string, error) {
@@ -534,5 +887,7 @@ func (u *updater) unallocatedSources(srcs map[string]*GoFile, rules []*edit.Rule
 			ret = append(ret, src)
 		}
 	}
+	// Sort the sources so the rules we generate don't depend on the order we happen to find them in
+	sort.Strings(ret)
 	return ret, nil
 }
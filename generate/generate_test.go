@@ -1,6 +1,9 @@
 package generate
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +12,7 @@ import (
 	"github.com/please-build/puku/config"
 	"github.com/please-build/puku/edit"
 	"github.com/please-build/puku/kinds"
+	"github.com/please-build/puku/logging"
 	"github.com/please-build/puku/options"
 	"github.com/please-build/puku/please"
 )
@@ -165,6 +169,326 @@ func TestAllocateSourcesToNonGoKind(t *testing.T) {
 	assert.ElementsMatch(t, []string{"foo.go"}, mustGetSources(t, u, newRules[0]))
 }
 
+func TestAllocateBenchmarksAndFuzzTests(t *testing.T) {
+	fuzzKind := &kinds.Kind{
+		Name:     "go_fuzz",
+		Type:     kinds.Fuzz,
+		SrcsAttr: "srcs",
+	}
+
+	files := map[string]*GoFile{
+		"foo.go": {
+			Name:     "foo",
+			FileName: "foo.go",
+		},
+		"foo_test.go": {
+			Name:     "foo",
+			FileName: "foo_test.go",
+			Tests:    []string{"TestFoo"},
+		},
+		"bench_test.go": {
+			Name:       "foo",
+			FileName:   "bench_test.go",
+			Benchmarks: []string{"BenchmarkFoo"},
+		},
+		"mixed_test.go": {
+			Name:       "foo",
+			FileName:   "mixed_test.go",
+			Tests:      []string{"TestBar"},
+			Benchmarks: []string{"BenchmarkBar"},
+		},
+		"fuzz_test.go": {
+			Name:      "foo",
+			FileName:  "fuzz_test.go",
+			FuzzTests: []string{"FuzzFoo"},
+		},
+	}
+
+	ptr := func(val bool) *bool {
+		return &val
+	}
+
+	t.Run("doesn't split by default", func(t *testing.T) {
+		u := newUpdater(new(please.Config), options.TestOptions)
+		newRules, err := u.allocateSources(new(config.Config), "foo", files, nil)
+		require.NoError(t, err)
+
+		rules := rulesByName(newRules)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "go_library", rules["foo"].Kind.Name)
+		assert.Equal(t, "go_test", rules["foo_test"].Kind.Name)
+		assert.ElementsMatch(t, []string{"foo_test.go", "bench_test.go", "mixed_test.go", "fuzz_test.go"}, mustGetSources(t, u, rules["foo_test"]))
+	})
+
+	t.Run("splits benchmarks and fuzz tests", func(t *testing.T) {
+		fuzz := edit.NewRule(edit.NewRuleExpr("go_fuzz", "foo_fuzz"), fuzzKind, "foo")
+		conf := &config.Config{SplitBenchmarks: ptr(true), SplitFuzzTests: ptr(true)}
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		newRules, err := u.allocateSources(conf, "foo", files, []*edit.Rule{fuzz})
+		require.NoError(t, err)
+
+		rules := rulesByName(newRules)
+		require.Len(t, rules, 3)
+		require.Contains(t, rules, "foo_benchmark")
+		require.Contains(t, rules, "foo_test")
+
+		assert.Equal(t, "go_benchmark", rules["foo_benchmark"].Kind.Name)
+		assert.ElementsMatch(t, []string{"bench_test.go"}, mustGetSources(t, u, rules["foo_benchmark"]))
+		assert.ElementsMatch(t, []string{"foo_test.go", "mixed_test.go"}, mustGetSources(t, u, rules["foo_test"]))
+		assert.ElementsMatch(t, []string{"fuzz_test.go"}, mustGetSources(t, u, fuzz))
+	})
+
+	t.Run("creates a go_fuzz_test target without a fuzz target", func(t *testing.T) {
+		conf := &config.Config{SplitFuzzTests: ptr(true)}
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		newRules, err := u.allocateSources(conf, "foo", files, nil)
+		require.NoError(t, err)
+
+		rules := rulesByName(newRules)
+		require.Len(t, rules, 3)
+		require.Contains(t, rules, "foo_fuzz_test")
+		assert.Equal(t, "go_fuzz_test", rules["foo_fuzz_test"].Kind.Name)
+		assert.ElementsMatch(t, []string{"fuzz_test.go"}, mustGetSources(t, u, rules["foo_fuzz_test"]))
+		assert.ElementsMatch(t, []string{"foo_test.go", "bench_test.go", "mixed_test.go"}, mustGetSources(t, u, rules["foo_test"]))
+	})
+}
+
+func TestAllocateSourcesWithNameTemplates(t *testing.T) {
+	files := map[string]*GoFile{
+		"foo.go": {
+			Name:     "foo",
+			FileName: "foo.go",
+		},
+		"foo_test.go": {
+			Name:     "foo",
+			FileName: "foo_test.go",
+			Tests:    []string{"TestFoo"},
+		},
+	}
+
+	conf := &config.Config{NameTemplates: map[string]string{"lib": "lib", "test": "{{.Package}}_{{.Dir}}_test"}}
+
+	u := newUpdater(new(please.Config), options.TestOptions)
+	newRules, err := u.allocateSources(conf, "pkg/foo_dir", files, nil)
+	require.NoError(t, err)
+
+	rules := rulesByName(newRules)
+	require.Len(t, rules, 2)
+	require.Contains(t, rules, "lib")
+	require.Contains(t, rules, "foo_foo_dir_test")
+	assert.Equal(t, "go_library", rules["lib"].Kind.Name)
+	assert.Equal(t, "go_test", rules["foo_foo_dir_test"].Kind.Name)
+}
+
+func TestAllocateSourcesWithDefaultKinds(t *testing.T) {
+	files := map[string]*GoFile{
+		"foo.go": {
+			Name:     "foo",
+			FileName: "foo.go",
+		},
+		"foo_test.go": {
+			Name:     "foo",
+			FileName: "foo_test.go",
+			Tests:    []string{"TestFoo"},
+		},
+	}
+
+	conf := &config.Config{
+		TestKinds: map[string]*config.KindConfig{
+			"company_go_test": {ProvidedDeps: []string{"//common/go:testing"}},
+		},
+		DefaultKinds: map[string]string{"test": "company_go_test"},
+	}
+
+	u := newUpdater(new(please.Config), options.TestOptions)
+	newRules, err := u.allocateSources(conf, "foo", files, nil)
+	require.NoError(t, err)
+
+	rules := rulesByName(newRules)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "go_library", rules["foo"].Rule.Kind())
+	assert.Equal(t, "company_go_test", rules["foo_test"].Rule.Kind())
+	assert.True(t, rules["foo_test"].Kind.IsProvided("//common/go:testing"))
+}
+
+func TestAllocateSourcesWithNewRuleAttrs(t *testing.T) {
+	files := map[string]*GoFile{
+		"foo_test.go": {
+			Name:     "foo",
+			FileName: "foo_test.go",
+			Tests:    []string{"TestFoo"},
+		},
+	}
+
+	conf := new(config.Config)
+	require.NoError(t, json.Unmarshal([]byte(`{"newRuleAttrs": {"go_test": {"size": "small", "labels": ["unit"], "srcs": ["ignored.go"]}}}`), conf))
+
+	u := newUpdater(new(please.Config), options.TestOptions)
+	newRules, err := u.allocateSources(conf, "foo", files, nil)
+	require.NoError(t, err)
+
+	require.Len(t, newRules, 1)
+	rule := newRules[0]
+	assert.Equal(t, "small", rule.AttrString("size"))
+	assert.Equal(t, []string{"unit"}, rule.AttrStrings("labels"))
+	assert.Equal(t, []string{"foo_test.go"}, rule.AttrStrings("srcs"))
+}
+
+func TestAllocateMultiplePackages(t *testing.T) {
+	files := map[string]*GoFile{
+		"bar.go": {
+			Name:     "bar",
+			FileName: "bar.go",
+		},
+		"bar_test.go": {
+			Name:     "bar",
+			FileName: "bar_test.go",
+			Tests:    []string{"TestBar"},
+		},
+		"foo.go": {
+			Name:     "foo",
+			FileName: "foo.go",
+		},
+		"foo_test.go": {
+			Name:     "foo",
+			FileName: "foo_test.go",
+			Tests:    []string{"TestFoo"},
+		},
+		"main.go": {
+			Name:     "main",
+			FileName: "main.go",
+		},
+	}
+
+	t.Run("generates a library per package", func(t *testing.T) {
+		// An existing empty rule should only be used for one of the packages
+		foo := edit.NewRule(edit.NewRuleExpr("go_library", "foo"), kinds.DefaultKinds["go_library"], "foo")
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		newRules, err := u.allocateSources(new(config.Config), "foo", files, []*edit.Rule{foo})
+		require.NoError(t, err)
+
+		rules := rulesByName(newRules)
+		require.Len(t, rules, 4)
+		assert.ElementsMatch(t, []string{"foo.go"}, mustGetSources(t, u, foo))
+		assert.ElementsMatch(t, []string{"bar.go"}, mustGetSources(t, u, rules["bar"]))
+		assert.ElementsMatch(t, []string{"foo_test.go"}, mustGetSources(t, u, rules["foo_test"]))
+		assert.ElementsMatch(t, []string{"bar_test.go"}, mustGetSources(t, u, rules["bar_test"]))
+		assert.ElementsMatch(t, []string{"main.go"}, mustGetSources(t, u, rules["main"]))
+	})
+
+	t.Run("errors when configured to", func(t *testing.T) {
+		errorOnMultiplePackages := true
+		conf := &config.Config{ErrorOnMultiplePackages: &errorOnMultiplePackages}
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		_, err := u.allocateSources(conf, "foo", files, nil)
+		assert.Error(t, err)
+	})
+
+	// allocatedRules returns rules for a directory that's already been set up with a library per package
+	allocatedRules := func() []*edit.Rule {
+		newRule := func(kind, name string, srcs ...string) *edit.Rule {
+			rule := edit.NewRule(edit.NewRuleExpr(kind, name), kinds.DefaultKinds[kind], "foo")
+			rule.SetAttr("srcs", edit.NewStringList(srcs))
+			return rule
+		}
+		return []*edit.Rule{
+			newRule("go_library", "foo", "foo.go"),
+			newRule("go_library", "bar", "bar.go"),
+			newRule("go_test", "foo_test", "foo_test.go"),
+			newRule("go_test", "bar_test", "bar_test.go"),
+			newRule("go_binary", "main", "main.go"),
+		}
+	}
+
+	t.Run("doesn't warn once the sources are allocated", func(t *testing.T) {
+		u := newUpdater(new(please.Config), options.TestOptions)
+		var newRules []*edit.Rule
+		warnings := logging.Capture(func() {
+			var err error
+			newRules, err = u.allocateSources(new(config.Config), "foo", files, allocatedRules())
+			require.NoError(t, err)
+		})
+		assert.Empty(t, newRules)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("still errors once the sources are allocated", func(t *testing.T) {
+		errorOnMultiplePackages := true
+		conf := &config.Config{ErrorOnMultiplePackages: &errorOnMultiplePackages}
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		_, err := u.allocateSources(conf, "foo", files, allocatedRules())
+		assert.Error(t, err)
+	})
+
+	t.Run("includes the package in names from fixed templates", func(t *testing.T) {
+		conf := &config.Config{NameTemplates: map[string]string{"lib": "lib"}}
+
+		u := newUpdater(new(please.Config), options.TestOptions)
+		newRules, err := u.allocateSources(conf, "foo", files, nil)
+		require.NoError(t, err)
+
+		rules := rulesByName(newRules)
+		assert.ElementsMatch(t, []string{"foo.go"}, mustGetSources(t, u, rules["lib"]))
+		assert.ElementsMatch(t, []string{"bar.go"}, mustGetSources(t, u, rules["bar_lib"]))
+	})
+}
+
+func TestUpdateTestData(t *testing.T) {
+	dir := t.TempDir()
+	newRule := func() *edit.Rule {
+		rule := edit.NewRule(edit.NewRuleExpr("go_test", "foo_test"), kinds.DefaultKinds["go_test"], dir)
+		rule.SetAttr("data", edit.NewStringList([]string{"//common:data"}))
+		return rule
+	}
+
+	t.Run("doesn't add testdata if the directory doesn't exist", func(t *testing.T) {
+		rule := newRule()
+		updateTestData(rule)
+		assert.Equal(t, []string{"//common:data"}, rule.AttrStrings("data"))
+	})
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "testdata"), 0755))
+
+	t.Run("adds testdata when the directory exists", func(t *testing.T) {
+		rule := newRule()
+		updateTestData(rule)
+		assert.Equal(t, []string{"//common:data", "testdata"}, rule.AttrStrings("data"))
+	})
+
+	t.Run("doesn't add testdata twice", func(t *testing.T) {
+		rule := newRule()
+		rule.SetAttr("data", edit.NewStringList([]string{"testdata", "//common:data"}))
+		updateTestData(rule)
+		assert.Equal(t, []string{"testdata", "//common:data"}, rule.AttrStrings("data"))
+	})
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "testdata")))
+
+	t.Run("removes testdata once the directory is gone", func(t *testing.T) {
+		rule := newRule()
+		rule.SetAttr("data", edit.NewStringList([]string{"//common:data", "testdata"}))
+		updateTestData(rule)
+		assert.Equal(t, []string{"//common:data"}, rule.AttrStrings("data"))
+	})
+}
+
+func TestUpdateFuzzCorpus(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "testdata", "fuzz", "FuzzFoo"), 0755))
+
+	rule := edit.NewRule(edit.NewRuleExpr("go_fuzz_test", "foo_fuzz_test"), kinds.DefaultKinds["go_fuzz_test"], dir)
+	rule.SetAttr("data", edit.NewStringList([]string{"testdata/golden.json", "testdata/fuzz/corpus", "testdata/fuzz/FuzzRemoved"}))
+
+	updateFuzzCorpus(rule, []string{"FuzzFoo", "FuzzBar"})
+	// The hand-written corpus entry isn't named after a fuzz test, so puku leaves it alone
+	assert.Equal(t, []string{"testdata/golden.json", "testdata/fuzz/corpus", "testdata/fuzz/FuzzFoo"}, rule.AttrStrings("data"))
+}
+
 func TestUpdateDeps(t *testing.T) {
 	type ruleKind struct {
 		kind *kinds.Kind
@@ -172,14 +496,15 @@ func TestUpdateDeps(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name         string
-		srcs         []*GoFile
-		rule         *ruleKind
-		expectedDeps []string
-		modules      []string
-		installs     map[string]string
-		conf         *config.Config
-		proxy        FakeProxy
+		name           string
+		srcs           []*GoFile
+		rule           *ruleKind
+		expectedDeps   []string
+		expectedIssues []string
+		modules        []string
+		installs       map[string]string
+		conf           *config.Config
+		proxy          FakeProxy
 	}{
 		{
 			name: "adds import from known module",
@@ -197,6 +522,51 @@ func TestUpdateDeps(t *testing.T) {
 			},
 			expectedDeps: []string{"///third_party/go/github.com_example_module//foo"},
 		},
+		{
+			name: "reports imports of internal packages from other modules",
+			srcs: []*GoFile{
+				{
+					FileName: "foo.go",
+					Imports:  []string{"github.com/example/module/internal/foo"},
+					Name:     "foo",
+				},
+			},
+			modules: []string{"github.com/example/module"},
+			rule: &ruleKind{
+				srcs: []string{"foo.go"},
+				kind: kinds.DefaultKinds["go_library"],
+			},
+			expectedDeps: []string{"///third_party/go/github.com_example_module//internal/foo"},
+			expectedIssues: []string{
+				`:rule: can't import internal package "github.com/example/module/internal/foo" from github.com/this/module`,
+			},
+		},
+		{
+			name: "reports dependency rule violations",
+			srcs: []*GoFile{
+				{
+					FileName: "foo.go",
+					Imports:  []string{"database/sql", "github.com/example/module/foo"},
+					Name:     "foo",
+				},
+			},
+			modules: []string{"github.com/example/module"},
+			rule: &ruleKind{
+				srcs: []string{"foo.go"},
+				kind: kinds.DefaultKinds["go_library"],
+			},
+			conf: &config.Config{
+				DependencyRules: []*config.DependencyRule{
+					{Name: "no sql", DenyImports: []string{"database/sql"}},
+					{Name: "no example", DenyDeps: []string{"///third_party/go/github.com_example_module//..."}},
+				},
+			},
+			expectedDeps: []string{"///third_party/go/github.com_example_module//foo"},
+			expectedIssues: []string{
+				`:rule: can't import "database/sql": denied by dependency rule "no sql"`,
+				`:rule: can't depend on ///third_party/go/github.com_example_module//foo for "github.com/example/module/foo": denied by dependency rule "no example"`,
+			},
+		},
 		{
 			name: "handles installs",
 			srcs: []*GoFile{
@@ -244,6 +614,50 @@ func TestUpdateDeps(t *testing.T) {
 			},
 			expectedDeps: []string{"///third_party/go/github.com_example_module//bar"},
 		},
+		{
+			name: "applies import rules to blank and dot imports",
+			srcs: []*GoFile{
+				{
+					FileName: "foo.go",
+					Imports: []string{
+						"github.com/example/module/ignored",
+						"github.com/example/module/redirected",
+						"github.com/example/module/forced",
+						"github.com/example/module/named",
+					},
+					ImportSpecs: []*Import{
+						{Path: "github.com/example/module/ignored", Name: "_"},
+						{Path: "github.com/example/module/redirected", Name: "."},
+						{Path: "github.com/example/module/forced", Name: "_"},
+						{Path: "github.com/example/module/named", Name: "named"},
+					},
+					Name: "foo",
+				},
+			},
+			modules: []string{"github.com/example/module"},
+			rule: &ruleKind{
+				srcs: []string{"foo.go"},
+				kind: &kinds.Kind{
+					Name: "example_library",
+					Type: kinds.Lib,
+					ProvidedDeps: []string{
+						"///third_party/go/github.com_example_module//forced",
+					},
+					ImportRules: map[string]*kinds.ImportRule{
+						"github.com/example/module/ignored":    {Action: kinds.IgnoreImport},
+						"github.com/example/module/redirected": {Action: kinds.RedirectImport, Target: "//common:redirected"},
+						"github.com/example/module/forced":     {Name: "_", Action: kinds.ForceImport},
+						// Only blank and dot imports are matched
+						"github.com/example/module/named": {Action: kinds.IgnoreImport},
+					},
+				},
+			},
+			expectedDeps: []string{
+				"//common:redirected",
+				"///third_party/go/github.com_example_module//forced",
+				"///third_party/go/github.com_example_module//named",
+			},
+		},
 		{
 			name:    "handles missing src",
 			srcs:    []*GoFile{},
@@ -293,10 +707,24 @@ func TestUpdateDeps(t *testing.T) {
 			require.NoError(t, err)
 			assert.ElementsMatch(t, tc.expectedDeps, r.AttrStrings("deps"))
 			assert.ElementsMatch(t, srcNames, r.AttrStrings(r.SrcsAttr()))
+
+			issues := make([]string, 0, len(u.issues))
+			for _, issue := range u.issues {
+				issues = append(issues, issue.String())
+			}
+			assert.ElementsMatch(t, tc.expectedIssues, issues)
 		})
 	}
 }
 
+func rulesByName(rules []*edit.Rule) map[string]*edit.Rule {
+	ret := make(map[string]*edit.Rule, len(rules))
+	for _, r := range rules {
+		ret[r.Name()] = r
+	}
+	return ret
+}
+
 func mustGetSources(t *testing.T, u *updater, rule *edit.Rule) []string {
 	t.Helper()
 
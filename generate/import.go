@@ -1,21 +1,46 @@
 package generate
 
 import (
+	"bytes"
+	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/please-build/puku/config"
 	"github.com/please-build/puku/kinds"
 )
 
+// testDataDir is the directory the go tool ignores when looking for packages, so is conventionally used for test data
+const testDataDir = "testdata"
+
 // GoFile represents a single Go file in a package
 type GoFile struct {
 	// Name is the name from the package clause of this file
 	Name, FileName string
 	// Imports are the imports of this file
 	Imports []string
+	// ImportSpecs contains the name and position of each of the imports of this file
+	ImportSpecs []*Import
+	// Tests, Benchmarks and FuzzTests are the names of the test functions declared in this file. These are only
+	// populated for test files. Examples are included in Tests.
+	Tests, Benchmarks, FuzzTests []string
+	// EmbedPatterns are the patterns from any //go:embed directives in this file
+	EmbedPatterns []string
+}
+
+// Import is a single import spec from a Go file
+type Import struct {
+	// Path is the import path
+	Path string
+	// Name is the name given to the import, if any. This is "_" for blank imports, and "." for dot imports.
+	Name string
+	// Pos is the position of the import spec in the file
+	Pos token.Position
 }
 
 // ImportDir does _some_ of what the go/build ImportDir does but is more permissive.
@@ -46,22 +71,88 @@ func ImportDir(dir string) (map[string]*GoFile, error) {
 }
 
 func importFile(dir, src string) (*GoFile, error) {
-	f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, src), nil, parser.ImportsOnly|parser.ParseComments)
+	path := filepath.Join(dir, src)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need the function declarations from test files to find any benchmarks and fuzz tests, and the comments on the
+	// variable declarations to find any //go:embed directives. Otherwise, we can stop after the imports.
+	mode := parser.ImportsOnly | parser.ParseComments
+	if isTestFile(src) || bytes.Contains(content, []byte(embedDirective)) {
+		mode = parser.ParseComments | parser.SkipObjectResolution
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, content, mode)
 	if err != nil {
 		return nil, err
 	}
 	imports := make([]string, 0, len(f.Imports))
+	specs := make([]*Import, 0, len(f.Imports))
 	for _, i := range f.Imports {
 		path := i.Path.Value
 		path = strings.Trim(path, `"`)
 		imports = append(imports, path)
+
+		spec := &Import{
+			Path: path,
+			Pos:  fset.Position(i.Pos()),
+		}
+		if i.Name != nil {
+			spec.Name = i.Name.Name
+		}
+		specs = append(specs, spec)
+	}
+
+	ret := &GoFile{
+		Name:          f.Name.Name,
+		FileName:      src,
+		Imports:       imports,
+		ImportSpecs:   specs,
+		EmbedPatterns: embedPatterns(f),
 	}
 
-	return &GoFile{
-		Name:     f.Name.Name,
-		FileName: src,
-		Imports:  imports,
-	}, nil
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		name := fn.Name.Name
+		switch {
+		case isTestFunc(name, "Test"), isTestFunc(name, "Example"):
+			ret.Tests = append(ret.Tests, name)
+		case isTestFunc(name, "Benchmark"):
+			ret.Benchmarks = append(ret.Benchmarks, name)
+		case isTestFunc(name, "Fuzz"):
+			ret.FuzzTests = append(ret.FuzzTests, name)
+		}
+	}
+	return ret, nil
+}
+
+// isTestFunc returns whether the function name is a test function with the given prefix. Like go test, the prefix must
+// not be followed by a lower case letter, so TestFoo is a test but Testify isn't.
+func isTestFunc(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// importSpec returns the import spec for the given import path, or nil if we don't have one
+func (f *GoFile) importSpec(path string) *Import {
+	for _, i := range f.ImportSpecs {
+		if i.Path == path {
+			return i
+		}
+	}
+	return nil
 }
 
 // IsExternal returns whether the test is external
@@ -70,15 +161,32 @@ func (f *GoFile) IsExternal(pkgName string) bool {
 }
 
 func (f *GoFile) IsTest() bool {
-	return strings.HasSuffix(f.FileName, "_test.go")
+	return isTestFile(f.FileName)
+}
+
+// IsBenchmarkOnly returns whether this is a test file that only contains benchmarks
+func (f *GoFile) IsBenchmarkOnly() bool {
+	return f.IsTest() && len(f.Benchmarks) > 0 && len(f.Tests) == 0 && len(f.FuzzTests) == 0
+}
+
+func isTestFile(fileName string) bool {
+	return strings.HasSuffix(fileName, "_test.go")
 }
 
 func (f *GoFile) IsCmd() bool {
 	return f.Name == "main"
 }
 
-func (f *GoFile) kindType() kinds.Type {
+// kindType returns the type of target this file should be allocated to. Benchmarks and fuzz tests are only split out
+// of the test target when configured to do so.
+func (f *GoFile) kindType(conf *config.Config) kinds.Type {
 	if f.IsTest() {
+		if len(f.FuzzTests) > 0 && conf.ShouldSplitFuzzTests() {
+			return kinds.Fuzz
+		}
+		if f.IsBenchmarkOnly() && conf.ShouldSplitBenchmarks() {
+			return kinds.Benchmark
+		}
 		return kinds.Test
 	}
 	if f.IsCmd() {
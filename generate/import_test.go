@@ -1,6 +1,8 @@
 package generate
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,6 +25,11 @@ func TestImportDir(t *testing.T) {
 	assert.Equal(t, fooTest.Imports, []string{"github.com/stretchr/testify/assert"})
 	assert.Equal(t, externalTest.Imports, []string{"github.com/stretchr/testify/require"})
 
+	require.Len(t, foo.ImportSpecs, 1)
+	assert.Equal(t, "github.com/example/module", foo.ImportSpecs[0].Path)
+	assert.Equal(t, "_", foo.ImportSpecs[0].Name)
+	assert.Equal(t, "test_project/foo/foo.go:3:8", foo.ImportSpecs[0].Pos.String())
+
 	assert.False(t, foo.IsTest())
 	assert.True(t, fooTest.IsTest())
 	assert.True(t, externalTest.IsTest())
@@ -45,3 +52,27 @@ func TestImportDir(t *testing.T) {
 	require.False(t, main.IsTest())
 	require.False(t, main.IsExternal("test_project"))
 }
+
+func TestImportTestFuncs(t *testing.T) {
+	dir := t.TempDir()
+	src := `package foo
+
+import "testing"
+
+func TestFoo(t *testing.T) {}
+func Testify(t *testing.T) {}
+func ExampleFoo() {}
+func BenchmarkFoo(b *testing.B) {}
+func FuzzFoo(f *testing.F) {}
+func (s *suite) TestMethod() {}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo_test.go"), []byte(src), 0644))
+
+	f, err := importFile(dir, "foo_test.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"TestFoo", "ExampleFoo"}, f.Tests)
+	assert.Equal(t, []string{"BenchmarkFoo"}, f.Benchmarks)
+	assert.Equal(t, []string{"FuzzFoo"}, f.FuzzTests)
+	assert.False(t, f.IsBenchmarkOnly())
+}
@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrIssuesFound is returned when puku finds problems with the targets it's updating that it can't fix itself
+var ErrIssuesFound = errors.New("puku found issues that need to be fixed by hand")
+
+// Issue is a problem with a target that puku can't fix itself, e.g. an import that isn't allowed
+type Issue struct {
+	// Target is the label of the target with the issue
+	Target string
+	// Pos is the position in the source file that caused the issue, if there is one
+	Pos string
+	// Message describes the issue
+	Message string
+}
+
+func (i *Issue) String() string {
+	if i.Pos == "" {
+		return fmt.Sprintf("%v: %v", i.Target, i.Message)
+	}
+	return fmt.Sprintf("%v: %v: %v", i.Pos, i.Target, i.Message)
+}
+
+// addIssue records an issue with the rule. The import spec is used for the position of the issue if it's not nil.
+func (u *updater) addIssue(rule string, spec *Import, message string) {
+	issue := &Issue{
+		Target:  rule,
+		Message: message,
+	}
+	if spec != nil {
+		issue.Pos = spec.Pos.String()
+	}
+	u.issues = append(u.issues, issue)
+}
+
+// writeIssues writes the issues to the writer in the given format, i.e. json or text. In json format, each issue is
+// wrapped in an object with a single Issue field, so they can be told apart from the build files written before them.
+func writeIssues(out io.Writer, format string, issues []*Issue) error {
+	for _, issue := range issues {
+		if format == "json" {
+			if err := json.NewEncoder(out).Encode(struct{ Issue *Issue }{Issue: issue}); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintln(out, issue.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isInternalViolation returns whether importing a package from another breaks Go's rule that internal packages can only
+// be imported from within the tree rooted at the parent of the internal directory.
+func isInternalViolation(importer, importPath string) bool {
+	parts := strings.Split(importPath, "/")
+	// The last internal directory is the most restrictive, so it's the only one we need to check
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] != "internal" {
+			continue
+		}
+		parent := strings.Join(parts[:i], "/")
+		return parent != "" && importer != parent && !strings.HasPrefix(importer, parent+"/")
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package generate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInternalViolation(t *testing.T) {
+	testCases := []struct {
+		importer, importPath string
+		expected             bool
+	}{
+		{importer: "example.com/foo", importPath: "example.com/bar", expected: false},
+		{importer: "example.com/foo", importPath: "example.com/foo/internal/bar", expected: false},
+		{importer: "example.com/foo/baz", importPath: "example.com/foo/internal/bar", expected: false},
+		{importer: "example.com/foo/internal/baz", importPath: "example.com/foo/internal/bar", expected: false},
+		{importer: "example.com/foobar", importPath: "example.com/foo/internal/bar", expected: true},
+		{importer: "example.com/other", importPath: "example.com/foo/internal", expected: true},
+		{importer: "example.com/foo/baz", importPath: "example.com/foo/internal/a/internal/b", expected: true},
+		{importer: "example.com/foo/internal/a/b", importPath: "example.com/foo/internal/a/internal/b", expected: false},
+		{importer: "example.com/foo", importPath: "internal/bar", expected: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isInternalViolation(tc.importer, tc.importPath), "importing %v from %v", tc.importPath, tc.importer)
+	}
+}
+
+func TestWriteIssues(t *testing.T) {
+	issues := []*Issue{
+		{Target: "//foo:foo", Pos: "foo/foo.go:3:2", Message: "can't import //bar:bar"},
+		{Target: "//baz:baz", Message: "import cycle"},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, writeIssues(out, "text", issues))
+		assert.Equal(t, "foo/foo.go:3:2: //foo:foo: can't import //bar:bar\n//baz:baz: import cycle\n", out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		assert.NoError(t, writeIssues(out, "json", issues))
+		assert.Equal(t, `{"Issue":{"Target":"//foo:foo","Pos":"foo/foo.go:3:2","Message":"can't import //bar:bar"}}
+{"Issue":{"Target":"//baz:baz","Pos":"","Message":"import cycle"}}
+`, out.String())
+	})
+}
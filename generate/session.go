@@ -0,0 +1,238 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/config"
+	"github.com/please-build/puku/eval"
+	"github.com/please-build/puku/fs"
+	"github.com/please-build/puku/glob"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+	"github.com/please-build/puku/trie"
+)
+
+// Session is a long-lived updater, used by watch mode. Rather than starting from scratch each time, it keeps the build
+// files of the packages it updates, and the third party modules and import resolutions it has loaded, between updates. These are invalidated as files
+// change, so each update only redoes the work affected by those changes.
+//
+// Sessions aren't safe for concurrent use.
+type Session struct {
+	plzConf *please.Config
+	opts    options.Options
+	u       *updater
+
+	// thirdPartyModTimes records when the third party build files were modified, for Refresh
+	thirdPartyModTimes string
+}
+
+// NewSession creates a new session
+func NewSession(plzConf *please.Config, opts options.Options) *Session {
+	return &Session{
+		plzConf: plzConf,
+		opts:    opts,
+		u:       newUpdater(plzConf, opts),
+	}
+}
+
+// SetOptions changes the options used by subsequent updates, e.g. because they were requested by different clients of
+// the daemon
+func (s *Session) SetOptions(opts options.Options) {
+	s.opts = opts
+	s.u.graph.SetOptions(opts)
+}
+
+// reset clears the state from the last update before starting another
+func (s *Session) reset() *updater {
+	u := s.u
+	u.issues = nil
+	u.newModules = nil
+	u.graph.ResetDependencies()
+	// Generated sources may have changed since the last update
+	u.eval.ResetTargets()
+	return u
+}
+
+// Update updates the build files for the packages in the given paths, in the same way as Update
+func (s *Session) Update(paths ...string) error {
+	u := s.reset()
+	err := u.update(paths...)
+	if err == nil {
+		for _, issue := range u.issues {
+			log.Warningf("%v", issue)
+		}
+		err = u.graph.FormatFiles()
+	}
+	if err != nil {
+		// We don't know what state the build files we've loaded are in, so start again from scratch next time
+		s.u = newUpdater(s.plzConf, s.opts)
+		return err
+	}
+
+	// We're only told when the files in the paths we're updating change, so any other build files we've loaded, e.g. to
+	// update their visibility, have to be read again next time in case they've been edited since
+	u.graph.InvalidateOthers(paths...)
+	return nil
+}
+
+// Lint writes the build files for the packages in the given paths to out, in the same way as UpdateToStdout
+func (s *Session) Lint(out io.Writer, format string, paths ...string) error {
+	u := s.reset()
+	// The build files we've loaded will have puku's changes in them, which we haven't written to disk
+	defer u.graph.InvalidateUnder(".")
+
+	if err := u.update(paths...); err != nil {
+		return err
+	}
+	return u.writeUpdates(out, format)
+}
+
+// Why returns the target an import resolves to for the package in the given directory. This is empty if the import
+// doesn't need a dependency, e.g. because it's part of the standard library.
+func (s *Session) Why(dir, importPath string) (string, error) {
+	u := s.u
+	if !u.modulesLoaded {
+		rootConf, err := config.ReadConfig(".")
+		if err != nil {
+			return "", err
+		}
+		if err := u.readAllModules(rootConf); err != nil {
+			return "", fmt.Errorf("failed to read third party rules: %v", err)
+		}
+		u.modulesLoaded = true
+	}
+
+	conf, err := config.ReadConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	return u.resolveImport(conf, importPath)
+}
+
+// Refresh drops everything the session has cached that may have changed on disk. The third party modules are kept if
+// their build files haven't changed since they were read. This is for when nothing is watching the repo to tell the
+// session which files have changed, e.g. in daemon mode.
+func (s *Session) Refresh() error {
+	config.InvalidateConfigs(".")
+
+	u := s.u
+	u.graph.InvalidateUnder(".")
+	u.eval = eval.New(glob.New(s.plzConf.BuildFileNames())).WithFileLoader(u.graph.LoadFile)
+	u.resolvedImports = map[string]string{}
+
+	conf, err := config.ReadConfig(".")
+	if err != nil {
+		return err
+	}
+	stamp, err := s.thirdPartyStamp(conf.GetThirdPartyDir())
+	if err != nil {
+		return err
+	}
+	if stamp != s.thirdPartyModTimes {
+		s.invalidateModules()
+		s.thirdPartyModTimes = stamp
+	}
+	return nil
+}
+
+// thirdPartyStamp returns the modification times of the build files in the third party directory, so we can tell when
+// they've changed
+func (s *Session) thirdPartyStamp(dir string) (string, error) {
+	stamp := new(strings.Builder)
+	err := filepath.WalkDir(dir, func(path string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !s.isBuildFile(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stamp, "%v %v\n", path, info.ModTime().UnixNano())
+		return nil
+	})
+	return stamp.String(), err
+}
+
+// Issues returns the problems found by the last update that puku couldn't fix itself
+func (s *Session) Issues() []*Issue {
+	return s.u.issues
+}
+
+// Invalidate tells the session that a file has been changed, added or removed, so it can drop anything it has cached
+// that depends on it.
+func (s *Session) Invalidate(file string) {
+	u := s.u
+	dir, base := filepath.Dir(file), filepath.Base(file)
+
+	switch {
+	case base == "puku.json":
+		// Config can change how any import is resolved, e.g. through knownTargets or the third party directory
+		u.resolvedImports = map[string]string{}
+	case base == "go.mod":
+		// The third party rules are about to be synced with the go.mod
+		s.invalidateModules()
+	case s.isBuildFile(base):
+		u.graph.Invalidate(dir)
+		// Recursive globs stop at package boundaries, which may have moved
+		u.eval.Invalidate(dir)
+		s.invalidateResolutions(dir)
+		if conf, err := config.ReadConfig(dir); err != nil || fs.IsSubdir(conf.GetThirdPartyDir(), dir) {
+			s.invalidateModules()
+		}
+	case filepath.Ext(base) == ".go":
+		// Files may have been added or removed, and the package clause may have changed
+		u.eval.Invalidate(dir)
+		s.invalidateResolutions(dir)
+	}
+}
+
+// invalidateModules forgets the third party modules, so they're read again on the next update
+func (s *Session) invalidateModules() {
+	u := s.u
+	u.modulesLoaded = false
+	u.modules = nil
+	u.installs = trie.New()
+	u.usingGoModule = false
+	u.resolvedImports = map[string]string{}
+
+	conf, err := config.ReadConfig(".")
+	if err != nil {
+		return
+	}
+	u.graph.InvalidateUnder(conf.GetThirdPartyDir())
+}
+
+// invalidateResolutions forgets how imports of the package in a directory, or imports that resolved to targets in that
+// package, were resolved
+func (s *Session) invalidateResolutions(dir string) {
+	u := s.u
+	importPath := path.Join(s.plzConf.ImportPath(), dir)
+	for i, target := range u.resolvedImports {
+		if i == importPath || (strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "///") && labels.Parse(target).Package == dir) {
+			delete(u.resolvedImports, i)
+		}
+	}
+}
+
+func (s *Session) isBuildFile(name string) bool {
+	for _, buildFileName := range s.plzConf.BuildFileNames() {
+		if name == buildFileName {
+			return true
+		}
+	}
+	return false
+}
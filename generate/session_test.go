@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/config"
+
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+func TestSessionInvalidate(t *testing.T) {
+	conf := new(please.Config)
+	conf.Plugin.Go.ImportPath = []string{"github.com/example/repo"}
+	conf.Parse.BuildFileName = []string{"BUILD"}
+
+	s := NewSession(conf, options.TestOptions)
+	resolved := func() map[string]string {
+		return map[string]string{
+			"github.com/example/repo/foo":        "//foo",
+			"github.com/example/repo/bar":        "//bar:lib",
+			"github.com/example/repo/baz/custom": "//foo:custom",
+			"github.com/some/module":             "///third_party/go/github.com_some_module//:module",
+		}
+	}
+
+	t.Run("go files invalidate the imports of and resolved to their package", func(t *testing.T) {
+		s.u.resolvedImports = resolved()
+		s.Invalidate("foo/foo.go")
+		assert.Equal(t, map[string]string{
+			"github.com/example/repo/bar": "//bar:lib",
+			"github.com/some/module":      "///third_party/go/github.com_some_module//:module",
+		}, s.u.resolvedImports)
+	})
+
+	t.Run("build files invalidate the imports of their package", func(t *testing.T) {
+		s.u.resolvedImports = resolved()
+		s.u.modulesLoaded = true
+		s.Invalidate("bar/BUILD")
+		assert.NotContains(t, s.u.resolvedImports, "github.com/example/repo/bar")
+		assert.Len(t, s.u.resolvedImports, 3)
+		assert.True(t, s.u.modulesLoaded)
+	})
+
+	t.Run("third party build files invalidate the modules", func(t *testing.T) {
+		s.u.resolvedImports = resolved()
+		s.u.modulesLoaded = true
+		s.u.modules = []string{"github.com/some/module"}
+		s.Invalidate("third_party/go/BUILD")
+		assert.Empty(t, s.u.resolvedImports)
+		assert.False(t, s.u.modulesLoaded)
+		assert.Empty(t, s.u.modules)
+	})
+
+	t.Run("config invalidates all resolved imports", func(t *testing.T) {
+		s.u.resolvedImports = resolved()
+		s.Invalidate("baz/puku.json")
+		assert.Empty(t, s.u.resolvedImports)
+	})
+}
+
+func TestSessionUpdateReadsOtherBuildFilesAgain(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		config.InvalidateConfigs(".")
+	})
+	config.InvalidateConfigs(".")
+
+	files := map[string]string{
+		"a/a.go":               "package a\n\nimport _ \"github.com/example/repo/b\"\n",
+		"b/b.go":               "package b\n",
+		"third_party/go/BUILD": "",
+		"b/BUILD":              "go_library(\n    name = \"b\",\n    srcs = [\"b.go\"],\n)\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0644))
+	}
+
+	conf := new(please.Config)
+	conf.Plugin.Go.ImportPath = []string{"github.com/example/repo"}
+	conf.Parse.BuildFileName = []string{"BUILD"}
+	s := NewSession(conf, options.TestOptions)
+
+	// Updating a makes b visible to it, which loads b/BUILD
+	require.NoError(t, s.Update("a"))
+	bs, err := os.ReadFile("b/BUILD")
+	require.NoError(t, err)
+	require.Contains(t, string(bs), "//a:all")
+
+	// b isn't being watched, so the session isn't told when the user changes its build file
+	edited := string(bs) + "\nfilegroup(\n    name = \"extra\",\n)\n"
+	require.NoError(t, os.WriteFile("b/BUILD", []byte(edited), 0644))
+
+	require.NoError(t, s.Update("a"))
+	bs, err = os.ReadFile("b/BUILD")
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(bs))
+}
@@ -1,95 +1,295 @@
 package glob
 
 import (
+	iofs "io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/please-build/puku/fs"
 )
 
 type pattern struct {
 	dir, glob string
+	options
+}
+
+// options are the arguments to a glob that change which files a pattern matches
+type options struct {
+	hidden, includeSymlinks, includeDirectories bool
 }
 
 type Globber struct {
-	cache map[pattern][]string
+	buildFileNames []string
+	cache          map[pattern][]string
 }
 
 type Args struct {
 	Include, Exclude []string
+	// Hidden is whether wildcards match hidden files and directories, i.e. ones whose names start with a .
+	Hidden bool
+	// IncludeSymlinks is whether to match symlinks to files
+	IncludeSymlinks bool
+	// IncludeDirectories is whether to match directories as well as files, i.e. exclude_directories = False
+	IncludeDirectories bool
 }
 
-func New() *Globber {
-	return &Globber{cache: map[pattern][]string{}}
+// New creates a new globber. Recursive globs don't descend into directories containing any of the build file names, as
+// they're separate packages.
+func New(buildFileNames []string) *Globber {
+	return &Globber{buildFileNames: buildFileNames, cache: map[pattern][]string{}}
 }
 
-// Glob is a specialised version of the glob builtin from Please. It assumes:
-// 1) globs should only match .go files as they're being used in go rules
-// 2) go rules will never depend on files outside the package dir, so we don't need to support **
-// 3) we don't want symlinks, directories and other non-regular files
+// Glob is a specialised version of the glob builtin from Please. It follows the same rules for matching files, i.e.
+// ** matches any number of directories but doesn't cross into other packages, wildcards don't match hidden files
+// unless Hidden is set, and excludes without a / match the base name of files. It assumes globs should only match .go
+// files, as they're being used in go rules. The files are returned relative to dir.
 func (g *Globber) Glob(dir string, args *Args) ([]string, error) {
+	opts := options{hidden: args.Hidden, includeSymlinks: args.IncludeSymlinks, includeDirectories: args.IncludeDirectories}
+
 	inc := map[string]struct{}{}
 	for _, i := range args.Include {
-		fs, err := g.glob(dir, i)
+		files, err := g.glob(dir, i, opts)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, f := range fs {
+		for _, f := range files {
 			inc[f] = struct{}{}
 		}
 	}
 
-	for _, i := range args.Exclude {
-		fs, err := g.glob(dir, i)
+	ret := make([]string, 0, len(inc))
+	for f := range inc {
+		excluded, err := isExcluded(f, args.Exclude)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, f := range fs {
-			delete(inc, f)
+		if !excluded {
+			ret = append(ret, f)
 		}
 	}
+	sort.Strings(ret)
+	return ret, nil
+}
 
-	ret := make([]string, 0, len(inc))
-	for i := range inc {
-		ret = append(ret, i)
+// Invalidate removes the cached results of globs that may include files in a directory, e.g. because files have been
+// added or removed
+func (g *Globber) Invalidate(dir string) {
+	for p := range g.cache {
+		if p.dir == dir || (strings.Contains(p.glob, "/") && (p.dir == "." || fs.IsSubdir(p.dir, dir))) {
+			delete(g.cache, p)
+		}
 	}
-	return ret, nil
 }
 
-// glob matches all regular files in a directory based on a glob pattern
-func (g *Globber) glob(dir, glob string) ([]string, error) {
-	p := pattern{dir: dir, glob: glob}
+// glob matches the files in a package based on a glob pattern
+func (g *Globber) glob(dir, glob string, opts options) ([]string, error) {
+	p := pattern{dir: dir, glob: glob, options: opts}
 	if res, ok := g.cache[p]; ok {
 		return res, nil
 	}
 
-	entries, err := os.ReadDir(dir)
+	// Start from the longest prefix of the pattern without any wildcards, so we don't have to search the whole package
+	parts := strings.Split(glob, "/")
+	base := 0
+	for base < len(parts)-1 && !hasMeta(parts[base]) {
+		base++
+	}
+	root := dir
+	for _, part := range parts[:base] {
+		root = filepath.Join(root, part)
+		if g.isPackage(root) {
+			// The files are in another package
+			g.cache[p] = nil
+			return nil, nil
+		}
+	}
+
+	// filepath.WalkDir doesn't follow symlinks, so resolve them first in case the package directory, or the prefix of the
+	// pattern, is one
+	root, err := filepath.EvalSymlinks(root)
 	if err != nil {
+		if base > 0 && os.IsNotExist(err) {
+			g.cache[p] = nil
+			return nil, nil
+		}
 		return nil, err
 	}
 
 	var files []string
-	for _, e := range entries {
-		// Ignore dirs, symlinks etc.
-		if !e.Type().IsRegular() {
-			continue
+	err = g.walk(root, parts[base:], opts, func(rel string) {
+		files = append(files, path.Join(append(parts[:base:base], rel)...))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.cache[p] = files
+	return files, nil
+}
+
+// walk calls fn with the path, relative to root, of each file under root that matches the pattern
+func (g *Globber) walk(root string, pattern []string, opts options, fn func(rel string)) error {
+	return filepath.WalkDir(root, func(p string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+
+		if d.IsDir() {
+			include := false
+			if opts.includeDirectories && filepath.Ext(d.Name()) == ".go" {
+				if include, err = match(pattern, segments, opts.hidden); err != nil {
+					return err
+				}
+			}
+			// Don't bother searching directories that can't contain any matches
+			descend, err := matchPrefix(pattern, segments, opts.hidden)
+			if err != nil {
+				return err
+			}
+			if (!include && !descend) || g.isPackage(p) || d.Name() == "plz-out" {
+				return filepath.SkipDir
+			}
+			if include {
+				fn(filepath.ToSlash(rel))
+			}
+			if !descend {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case d.Type().IsRegular():
+		case d.Type()&iofs.ModeSymlink != 0:
+			// We don't follow symlinks to directories, so only include symlinks to files
+			if !opts.includeSymlinks {
+				return nil
+			}
+			if info, err := os.Stat(p); err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		default:
+			return nil
 		}
 
 		// We're globbing for Go files to determine their imports. We can skip any other files.
-		if filepath.Ext(e.Name()) != ".go" {
-			continue
+		if filepath.Ext(d.Name()) != ".go" {
+			return nil
 		}
+		if ok, err := match(pattern, segments, opts.hidden); err != nil {
+			return err
+		} else if ok {
+			fn(filepath.ToSlash(rel))
+		}
+		return nil
+	})
+}
 
-		match, err := filepath.Match(glob, e.Name())
-		if err != nil {
-			return nil, err
+// isPackage returns whether the directory contains a build file
+func (g *Globber) isPackage(dir string) bool {
+	for _, name := range g.buildFileNames {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return true
 		}
+	}
+	return false
+}
 
-		if match {
-			files = append(files, e.Name())
+// match returns whether the path segments match the pattern segments. ** matches any number of segments.
+func match(pattern, segments []string, hidden bool) (bool, error) {
+	if len(pattern) == 0 {
+		return len(segments) == 0, nil
+	}
+	if pattern[0] == "**" {
+		if ok, err := match(pattern[1:], segments, hidden); ok || err != nil {
+			return ok, err
 		}
+		if len(segments) == 0 || (!hidden && isHidden(segments[0])) {
+			return false, nil
+		}
+		return match(pattern, segments[1:], hidden)
+	}
+	if len(segments) == 0 {
+		return false, nil
 	}
+	if ok, err := matchSegment(pattern[0], segments[0], hidden); !ok || err != nil {
+		return false, err
+	}
+	return match(pattern[1:], segments[1:], hidden)
+}
 
-	g.cache[p] = files
-	return files, nil
+// matchPrefix returns whether the segments of a directory could be the start of a path that matches the pattern
+func matchPrefix(pattern, segments []string, hidden bool) (bool, error) {
+	if len(segments) == 0 {
+		return len(pattern) > 0, nil
+	}
+	if len(pattern) == 0 {
+		return false, nil
+	}
+	if pattern[0] == "**" {
+		if ok, err := matchPrefix(pattern[1:], segments, hidden); ok || err != nil {
+			return ok, err
+		}
+		if !hidden && isHidden(segments[0]) {
+			return false, nil
+		}
+		return matchPrefix(pattern, segments[1:], hidden)
+	}
+	if ok, err := matchSegment(pattern[0], segments[0], hidden); !ok || err != nil {
+		return false, err
+	}
+	return matchPrefix(pattern[1:], segments[1:], hidden)
+}
+
+// matchSegment matches a single file or directory name. Wildcards only match hidden names if hidden is set, but they
+// can always be matched by name.
+func matchSegment(pattern, name string, hidden bool) (bool, error) {
+	if !hidden && isHidden(name) && !isHidden(pattern) {
+		return false, nil
+	}
+	return filepath.Match(pattern, name)
+}
+
+// isExcluded returns whether any of the excludes match the file, or one of the directories it's in. Like Please,
+// excludes without a / match the names of the file and its directories rather than the whole path.
+func isExcluded(file string, excludes []string) (bool, error) {
+	segments := strings.Split(file, "/")
+	for _, exclude := range excludes {
+		if !strings.Contains(exclude, "/") {
+			for _, segment := range segments {
+				if ok, err := filepath.Match(exclude, segment); ok || err != nil {
+					return ok, err
+				}
+			}
+			continue
+		}
+
+		pattern := strings.Split(exclude, "/")
+		for i := 1; i <= len(segments); i++ {
+			if ok, err := match(pattern, segments[:i], true); ok || err != nil {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+func hasMeta(segment string) bool {
+	return segment == "**" || strings.ContainsAny(segment, `*?[\`)
 }
@@ -1,6 +1,8 @@
 package glob
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,7 +10,7 @@ import (
 )
 
 func TestGlob(t *testing.T) {
-	g := &Globber{cache: map[pattern][]string{}}
+	g := New([]string{"BUILD_FILE", "BUILD_FILE.plz"})
 	t.Run("globs go files only", func(t *testing.T) {
 		files, err := g.Glob("test_project", &Args{
 			Include: []string{"*_test.go"},
@@ -27,4 +29,137 @@ func TestGlob(t *testing.T) {
 
 		assert.ElementsMatch(t, []string{"main.go", "bar.go"}, files)
 	})
+
+	t.Run("includes symlinks", func(t *testing.T) {
+		files, err := g.Glob("test_project", &Args{
+			Include:         []string{"*.go"},
+			IncludeSymlinks: true,
+		})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"main.go", "bar.go", "bar_test.go", "symlink.go"}, files)
+	})
+
+	t.Run("recursive globs stop at packages", func(t *testing.T) {
+		files, err := g.Glob("test_project", &Args{
+			Include: []string{"**/*.go"},
+		})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"main.go", "bar.go", "bar_test.go"}, files)
+	})
+}
+
+func TestRecursiveGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{
+		"a.go",
+		".hidden.go",
+		"sub/b.go",
+		"sub/b_test.go",
+		"sub/deeper/c.go",
+		".hidden/d.go",
+		"pkg/BUILD",
+		"pkg/e.go",
+		"plz-out/gen/f.go",
+	} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+	}
+
+	testCases := []struct {
+		name     string
+		args     *Args
+		expected []string
+	}{
+		{
+			name:     "matches any number of directories",
+			args:     &Args{Include: []string{"**/*.go"}},
+			expected: []string{"a.go", "sub/b.go", "sub/b_test.go", "sub/deeper/c.go"},
+		},
+		{
+			name:     "includes hidden files",
+			args:     &Args{Include: []string{"**/*.go"}, Hidden: true},
+			expected: []string{".hidden.go", ".hidden/d.go", "a.go", "sub/b.go", "sub/b_test.go", "sub/deeper/c.go"},
+		},
+		{
+			name:     "matches hidden files by name",
+			args:     &Args{Include: []string{".hidden.go"}},
+			expected: []string{".hidden.go"},
+		},
+		{
+			name:     "matches files in a subdirectory",
+			args:     &Args{Include: []string{"sub/*.go"}},
+			expected: []string{"sub/b.go", "sub/b_test.go"},
+		},
+		{
+			name:     "doesn't match files in other packages",
+			args:     &Args{Include: []string{"pkg/*.go"}},
+			expected: []string{},
+		},
+		{
+			name:     "excludes match base names",
+			args:     &Args{Include: []string{"**/*.go"}, Exclude: []string{"*_test.go"}},
+			expected: []string{"a.go", "sub/b.go", "sub/deeper/c.go"},
+		},
+		{
+			name:     "excludes match directories",
+			args:     &Args{Include: []string{"**/*.go"}, Exclude: []string{"sub/deeper"}},
+			expected: []string{"a.go", "sub/b.go", "sub/b_test.go"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := New([]string{"BUILD"}).Glob(dir, test.args)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, files)
+		})
+	}
+}
+
+func TestGlobSymlinkedDirectories(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"real/a.go", "real/sub/b.go"} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+	}
+	require.NoError(t, os.Symlink("real", filepath.Join(dir, "link")))
+
+	t.Run("globs a symlinked package", func(t *testing.T) {
+		files, err := New([]string{"BUILD"}).Glob(filepath.Join(dir, "link"), &Args{Include: []string{"**/*.go"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.go", "sub/b.go"}, files)
+	})
+
+	t.Run("globs through a symlinked prefix", func(t *testing.T) {
+		files, err := New([]string{"BUILD"}).Glob(dir, &Args{Include: []string{"link/sub/*.go"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"link/sub/b.go"}, files)
+	})
+}
+
+func TestInvalidate(t *testing.T) {
+	g := New(nil)
+	g.cache[pattern{dir: "foo", glob: "*.go"}] = []string{"foo.go"}
+	g.cache[pattern{dir: "foo", glob: "*_test.go"}] = []string{"foo_test.go"}
+	g.cache[pattern{dir: "bar", glob: "*.go"}] = []string{"bar.go"}
+
+	g.Invalidate("foo")
+	assert.Equal(t, map[pattern][]string{{dir: "bar", glob: "*.go"}: {"bar.go"}}, g.cache)
+
+	t.Run("invalidates recursive globs in parent directories", func(t *testing.T) {
+		g := New(nil)
+		g.cache[pattern{dir: "foo", glob: "**/*.go"}] = []string{"foo.go", "bar/bar.go"}
+		g.cache[pattern{dir: "foo", glob: "*.go"}] = []string{"foo.go"}
+		g.cache[pattern{dir: "baz", glob: "**/*.go"}] = []string{"baz.go"}
+
+		g.Invalidate("foo/bar")
+		assert.Equal(t, map[pattern][]string{
+			{dir: "foo", glob: "*.go"}:    {"foo.go"},
+			{dir: "baz", glob: "**/*.go"}: {"baz.go"},
+		}, g.cache)
+	})
 }
@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/please-build/buildtools/labels"
+
+	"github.com/please-build/puku/edit"
+)
+
+// AddTarget records a target in the graph, even if it doesn't have any dependencies
+func (g *Graph) AddTarget(label string) {
+	key := labels.Parse(label).Format()
+	if _, ok := g.edges[key]; !ok {
+		g.edges[key] = nil
+	}
+}
+
+// AddDependency records a dependency between two targets, caused by the given import if it's not empty
+func (g *Graph) AddDependency(from, to, importPath string) {
+	fromLabel := labels.Parse(from)
+	toLabel := labels.ParseRelative(to, fromLabel.Package)
+	key := fromLabel.Format()
+
+	dep := findDependency(g.edges[key], toLabel)
+	if dep == nil {
+		dep = &Dependency{From: fromLabel, To: toLabel, Imports: []string{}}
+		g.edges[key] = append(g.edges[key], dep)
+	}
+	if importPath == "" {
+		return
+	}
+	for _, i := range dep.Imports {
+		if i == importPath {
+			return
+		}
+	}
+	dep.Imports = append(dep.Imports, importPath)
+}
+
+func findDependency(deps []*Dependency, to labels.Label) *Dependency {
+	for _, dep := range deps {
+		if dep.To == to {
+			return dep
+		}
+	}
+	return nil
+}
+
+// Targets returns the targets recorded via AddTarget or AddDependency, in order
+func (g *Graph) Targets() []string {
+	ret := make([]string, 0, len(g.edges))
+	for target := range g.edges {
+		ret = append(ret, target)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Dependencies returns the dependencies of a target recorded via AddDependency, in the order they were added
+func (g *Graph) Dependencies(target string) []*Dependency {
+	return g.edges[labels.Parse(target).Format()]
+}
+
+// isSubrepo returns whether the label is for a target in a subrepo. These can't form a cycle with targets in this repo.
+func isSubrepo(label labels.Label) bool {
+	return strings.HasPrefix(label.Format(), "///") || label.Repository != ""
+}
+
+// loadDependencies returns the dependencies of a target we haven't updated from the deps in its build file, so we can
+// find cycles that pass through packages outside of the paths being updated. We don't know which imports caused these.
+// Build files that aren't already in the graph are read into files rather than the graph, so they're never formatted.
+func (g *Graph) loadDependencies(node string, files map[string]*build.File) []*Dependency {
+	label := labels.Parse(node)
+	file, ok := g.files[label.Package]
+	if !ok {
+		if file, ok = files[label.Package]; !ok {
+			f, err := g.loadFile(label.Package)
+			if err != nil {
+				log.Debugf("failed to load the dependencies of %v: %v", node, err)
+			}
+			files[label.Package] = f
+			file = f
+		}
+	}
+	if file == nil {
+		return nil
+	}
+	rule := edit.FindTargetByName(file, label.Target)
+	if rule == nil {
+		return nil
+	}
+
+	var deps []*Dependency
+	for _, dep := range rule.AttrStrings("deps") {
+		to := labels.ParseRelative(dep, label.Package)
+		if findDependency(deps, to) == nil {
+			deps = append(deps, &Dependency{From: label, To: to})
+		}
+	}
+	return deps
+}
+
+// FindCycles returns the cycles in the dependencies added via AddDependency, loading the dependencies of any other
+// targets they lead to from their build files. Dependencies on subrepos can't form a cycle with targets in this repo,
+// so they're ignored. Each cycle is the chain of dependencies that form it, starting from the dependency with the
+// lowest label.
+func (g *Graph) FindCycles() [][]*Dependency {
+	nodes := make([]string, 0, len(g.edges))
+	for node := range g.edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	found := map[string]struct{}{}
+	loaded := map[string][]*Dependency{}
+	files := map[string]*build.File{}
+	dependencies := func(node string) []*Dependency {
+		if deps, ok := g.edges[node]; ok {
+			return deps
+		}
+		if _, ok := loaded[node]; !ok {
+			loaded[node] = g.loadDependencies(node, files)
+		}
+		return loaded[node]
+	}
+
+	var cycles [][]*Dependency
+	var path []*Dependency
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = visiting
+		for _, dep := range dependencies(node) {
+			if isSubrepo(dep.To) {
+				continue
+			}
+			to := dep.To.Format()
+			switch state[to] {
+			case unvisited:
+				path = append(path, dep)
+				visit(to)
+				path = path[:len(path)-1]
+			case visiting:
+				// We've found a dependency back to a target on the current path, so the path from that target forms a
+				// cycle
+				start := len(path)
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i].From.Format() == to {
+						start = i
+						break
+					}
+				}
+				cycle := append(append([]*Dependency{}, path[start:]...), dep)
+				cycle = rotateCycle(cycle)
+
+				key := FormatCycle(cycle)
+				if _, ok := found[key]; !ok {
+					found[key] = struct{}{}
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		state[node] = visited
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+	return cycles
+}
+
+// rotateCycle rotates the cycle so it starts from the dependency with the lowest label, so the same cycle is always
+// reported the same way.
+func rotateCycle(cycle []*Dependency) []*Dependency {
+	lowest := 0
+	for i, dep := range cycle {
+		if dep.From.Format() < cycle[lowest].From.Format() {
+			lowest = i
+		}
+	}
+	return append(cycle[lowest:], cycle[:lowest]...)
+}
+
+// FormatCycle formats the cycle as the chain of labels in it, along with an import that caused each dependency, e.g.
+// //foo -> //bar (import "example.com/bar") -> //foo (import "example.com/foo")
+func FormatCycle(cycle []*Dependency) string {
+	if len(cycle) == 0 {
+		return ""
+	}
+
+	sb := new(strings.Builder)
+	sb.WriteString(cycle[0].From.Format())
+	for _, dep := range cycle {
+		fmt.Fprintf(sb, " -> %v", dep.To.Format())
+		if len(dep.Imports) > 0 {
+			fmt.Fprintf(sb, " (import %q)", dep.Imports[0])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"os"
+	"testing"
+
+	"github.com/please-build/buildtools/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/options"
+)
+
+func TestFindCycles(t *testing.T) {
+	t.Run("no cycles", func(t *testing.T) {
+		g := New(nil, options.TestOptions)
+		g.AddDependency("//a", "//b", "example.com/b")
+		g.AddDependency("//b", "//c", "example.com/c")
+		g.AddDependency("//a", "//c", "example.com/c")
+		g.AddDependency("//c", "///third_party/go/example.com_a//a", "example.com/a")
+
+		assert.Empty(t, g.FindCycles())
+	})
+
+	t.Run("finds cycles", func(t *testing.T) {
+		g := New(nil, options.TestOptions)
+		g.AddDependency("//c", "//a", "example.com/a")
+		g.AddDependency("//a", "//b:lib", "example.com/b")
+		g.AddDependency("//b:lib", "//c", "example.com/c")
+		g.AddDependency("//d", "//a", "example.com/a")
+		g.AddDependency("//d", ":other", "example.com/d/other")
+		g.AddDependency("//d:other", "//d", "example.com/d")
+
+		cycles := g.FindCycles()
+		require.Len(t, cycles, 2)
+		assert.Equal(t, `//a -> //b:lib (import "example.com/b") -> //c (import "example.com/c") -> //a (import "example.com/a")`, FormatCycle(cycles[0]))
+		assert.Equal(t, `//d -> //d:other (import "example.com/d/other") -> //d (import "example.com/d")`, FormatCycle(cycles[1]))
+	})
+
+	t.Run("finds cycles through targets that weren't updated", func(t *testing.T) {
+		b, err := build.ParseBuild("b/BUILD", []byte(`
+go_library(
+	name = "b",
+	srcs = ["b.go"],
+	deps = [":c", "///third_party/go/example.com_x//x"],
+)
+
+go_library(
+	name = "c",
+	srcs = ["c.go"],
+	deps = ["//a"],
+)
+`))
+		require.NoError(t, err)
+
+		g := New(nil, options.TestOptions)
+		g.SetFile("b", b)
+		g.AddDependency("//a", "//b", "example.com/b")
+
+		cycles := g.FindCycles()
+		require.Len(t, cycles, 1)
+		assert.Equal(t, `//a -> //b (import "example.com/b") -> //b:c -> //a`, FormatCycle(cycles[0]))
+	})
+
+	t.Run("doesn't add the build files it reads to the graph", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(t.TempDir()))
+		t.Cleanup(func() { _ = os.Chdir(wd) })
+
+		require.NoError(t, os.Mkdir("b", 0755))
+		require.NoError(t, os.WriteFile("b/BUILD", []byte(`go_library(name = "b", deps = ["//a"])`), 0644))
+
+		g := New([]string{"BUILD"}, options.TestOptions)
+		g.AddDependency("//a", "//b", "example.com/b")
+
+		cycles := g.FindCycles()
+		require.Len(t, cycles, 1)
+		assert.Equal(t, `//a -> //b (import "example.com/b") -> //a`, FormatCycle(cycles[0]))
+
+		// Otherwise, formatting the graph would write files outside the paths being updated
+		assert.NotContains(t, g.files, "b")
+	})
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/please-build/puku/config"
 	"github.com/please-build/puku/edit"
+	"github.com/please-build/puku/fs"
 	"github.com/please-build/puku/logging"
 	"github.com/please-build/puku/options"
 )
@@ -22,6 +23,8 @@ var log = logging.GetLogger()
 
 type Dependency struct {
 	From, To labels.Label
+	// Imports are the import paths that caused the dependency, if known
+	Imports []string
 }
 
 type Graph struct {
@@ -30,6 +33,10 @@ type Graph struct {
 	deps             []*Dependency
 	experimentalDirs []string
 	opts             options.Options
+
+	// edges are the dependencies of the targets updated in this run, keyed by the label they're from. These are used
+	// to detect cycles, and to export the dependency graph.
+	edges map[string][]*Dependency
 }
 
 func New(buildFileNames []string, opts options.Options) *Graph {
@@ -37,9 +44,15 @@ func New(buildFileNames []string, opts options.Options) *Graph {
 		buildFileNames: buildFileNames,
 		files:          map[string]*build.File{},
 		opts:           opts,
+		edges:          map[string][]*Dependency{},
 	}
 }
 
+// SetOptions changes the options used to format the build files
+func (g *Graph) SetOptions(opts options.Options) {
+	g.opts = opts
+}
+
 func (g *Graph) WithExperimentalDirs(dirs ...string) *Graph {
 	g.experimentalDirs = dirs
 	return g
@@ -61,6 +74,41 @@ func (g *Graph) LoadFile(path string) (*build.File, error) {
 	return f, nil
 }
 
+// Invalidate removes the build file in a directory from the graph, so it's read again from disk the next time it's
+// loaded
+func (g *Graph) Invalidate(path string) {
+	delete(g.files, path)
+}
+
+// InvalidateUnder removes the build files in a directory, and all the directories under it, from the graph
+func (g *Graph) InvalidateUnder(dir string) {
+	for path := range g.files {
+		if dir == "." || fs.IsSubdir(dir, path) {
+			delete(g.files, path)
+		}
+	}
+}
+
+// InvalidateOthers removes the build files in every directory other than the given ones from the graph
+func (g *Graph) InvalidateOthers(paths ...string) {
+	keep := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		keep[filepath.Clean(path)] = struct{}{}
+	}
+	for path := range g.files {
+		if _, ok := keep[path]; !ok {
+			delete(g.files, path)
+		}
+	}
+}
+
+// ResetDependencies forgets the dependencies recorded so far, so they aren't checked again the next time the files are
+// formatted
+func (g *Graph) ResetDependencies() {
+	g.deps = nil
+	g.edges = map[string][]*Dependency{}
+}
+
 // SetFile can be used to override a filepath with a given build file. This is useful for testing.
 func (g *Graph) SetFile(path string, file *build.File) {
 	g.files[path] = file
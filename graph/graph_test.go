@@ -242,3 +242,33 @@ func TestGetDefaultVisibilityFromFile(t *testing.T) {
 
 	assert.Equal(t, []string{"PUBLIC"}, getDefaultVisibility(file))
 }
+
+func TestInvalidateUnder(t *testing.T) {
+	g := New([]string{"BUILD"}, options.TestOptions)
+	for _, path := range []string{"third_party/go", "third_party/go/foo", "third_party/gopher", "foo"} {
+		g.SetFile(path, &build.File{Path: path + "/BUILD"})
+	}
+
+	g.InvalidateUnder("third_party/go")
+	assert.Len(t, g.files, 2)
+	assert.Contains(t, g.files, "third_party/gopher")
+	assert.Contains(t, g.files, "foo")
+
+	g.Invalidate("foo")
+	assert.NotContains(t, g.files, "foo")
+
+	g.InvalidateUnder(".")
+	assert.Empty(t, g.files)
+}
+
+func TestInvalidateOthers(t *testing.T) {
+	g := New([]string{"BUILD"}, options.TestOptions)
+	for _, path := range []string{"third_party/go", "foo", "foo/bar", "baz"} {
+		g.SetFile(path, &build.File{Path: path + "/BUILD"})
+	}
+
+	g.InvalidateOthers("foo", "baz/")
+	assert.Len(t, g.files, 2)
+	assert.Contains(t, g.files, "foo")
+	assert.Contains(t, g.files, "baz")
+}
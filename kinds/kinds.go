@@ -1,5 +1,7 @@
 package kinds
 
+import "fmt"
+
 type Type int
 
 const (
@@ -7,8 +9,35 @@ const (
 	Test
 	Bin
 	ThirdParty
+	Benchmark
+	Fuzz
 )
 
+// String returns the name used to refer to the type in config, e.g. "lib" or "test"
+func (t Type) String() string {
+	switch t {
+	case Lib:
+		return "lib"
+	case Test:
+		return "test"
+	case Bin:
+		return "bin"
+	case ThirdParty:
+		return "third_party"
+	case Benchmark:
+		return "benchmark"
+	case Fuzz:
+		return "fuzz"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// IsTest returns whether the type is a test, benchmark or fuzz test. These can't be depended on by other targets, and
+// are built against the library for the same package.
+func (t Type) IsTest() bool {
+	return t == Test || t == Benchmark || t == Fuzz
+}
+
 // Kind is a kind of build target, e.g. go_library. These can either be library, test or binaries. They can also provide
 // dependencies e.g. you could wrap go_test to add a common testing library, in which case, we should not add it as a
 // dep.
@@ -18,9 +47,56 @@ type Kind struct {
 	ProvidedDeps      []string
 	DefaultVisibility []string
 	SrcsAttr          string
+	// DataAttr is the attribute puku adds data files to, e.g. the seed corpus for fuzz tests.
+	DataAttr string
+	// EmbedAttr is the attribute puku adds the files embedded with //go:embed to
+	EmbedAttr string
+	// TestData indicates that puku should keep the testdata directory in the data of test targets of this kind when
+	// it exists.
+	TestData bool
 	// NonGoSources indicates the puku that the sources to this rule are not go so we shouldn't try to parse them to
 	// infer their deps, for example, proto_library.
 	NonGoSources bool
+	// ImportRules configures how blank (`_`) and dot (`.`) imports are handled for this kind, keyed by import path.
+	ImportRules map[string]*ImportRule
+}
+
+// ImportAction is what puku should do when it finds an import that matches an ImportRule
+type ImportAction string
+
+const (
+	// IgnoreImport skips the import entirely, e.g. because the dependency is provided some other way
+	IgnoreImport ImportAction = "ignore"
+	// ForceImport always adds the dependency for the import, even if it's one of the kind's provided deps
+	ForceImport ImportAction = "force"
+	// RedirectImport uses the rule's target as the dependency instead of resolving the import
+	RedirectImport ImportAction = "redirect"
+)
+
+// ImportRule configures how puku treats a blank or dot import. Blank imports are often only there for their side
+// effects, e.g. registering a database driver, so the dependency might be provided somewhere else.
+type ImportRule struct {
+	// Name restricts the rule to either blank ("_") or dot (".") imports. The rule applies to both if this is empty.
+	Name   string       `json:"name"`
+	Action ImportAction `json:"action"`
+	// Target is the dependency to use for the "redirect" action.
+	Target string `json:"target"`
+}
+
+// ImportRule returns the rule for an import with the given path and name, or nil if there isn't one. Only blank and dot
+// imports can match a rule.
+func (k *Kind) ImportRule(path, name string) *ImportRule {
+	if name != "_" && name != "." {
+		return nil
+	}
+	rule, ok := k.ImportRules[path]
+	if !ok {
+		return nil
+	}
+	if rule.Name != "" && rule.Name != name {
+		return nil
+	}
+	return rule
 }
 
 // IsProvided returns whether the dependency is already provided by the kind, and therefore can be omitted from the deps
@@ -37,24 +113,40 @@ func (k *Kind) IsProvided(i string) bool {
 // DefaultKinds are the base kinds that puku supports out of the box
 var DefaultKinds = map[string]*Kind{
 	"go_library": {
-		Name:     "go_library",
-		Type:     Lib,
-		SrcsAttr: "srcs",
+		Name:      "go_library",
+		Type:      Lib,
+		SrcsAttr:  "srcs",
+		EmbedAttr: "embed_srcs",
 	},
 	"go_binary": {
-		Name:     "go_binary",
-		Type:     Bin,
-		SrcsAttr: "srcs",
+		Name:      "go_binary",
+		Type:      Bin,
+		SrcsAttr:  "srcs",
+		EmbedAttr: "embed_srcs",
 	},
 	"go_test": {
-		Name:     "go_test",
-		Type:     Test,
-		SrcsAttr: "srcs",
+		Name:      "go_test",
+		Type:      Test,
+		SrcsAttr:  "srcs",
+		DataAttr:  "data",
+		EmbedAttr: "embed_srcs",
+		TestData:  true,
 	},
 	"go_benchmark": {
-		Name:     "go_benchmark",
-		Type:     Test,
-		SrcsAttr: "srcs",
+		Name:      "go_benchmark",
+		Type:      Benchmark,
+		SrcsAttr:  "srcs",
+		DataAttr:  "data",
+		EmbedAttr: "embed_srcs",
+		TestData:  true,
+	},
+	"go_fuzz_test": {
+		Name:      "go_fuzz_test",
+		Type:      Fuzz,
+		SrcsAttr:  "srcs",
+		DataAttr:  "data",
+		EmbedAttr: "embed_srcs",
+		TestData:  true,
 	},
 	"proto_library": {
 		Name:         "proto_library",
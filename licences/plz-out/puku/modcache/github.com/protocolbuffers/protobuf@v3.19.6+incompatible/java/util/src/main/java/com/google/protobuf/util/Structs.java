@@ -0,0 +1,65 @@
+// Protocol Buffers - Google's data interchange format
+// Copyright 2008 Google Inc.  All rights reserved.
+// https://developers.google.com/protocol-buffers/
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are
+// met:
+//
+//     * Redistributions of source code must retain the above copyright
+// notice, this list of conditions and the following disclaimer.
+//     * Redistributions in binary form must reproduce the above
+// copyright notice, this list of conditions and the following disclaimer
+// in the documentation and/or other materials provided with the
+// distribution.
+//     * Neither the name of Google Inc. nor the names of its
+// contributors may be used to endorse or promote products derived from
+// this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR
+// A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+// OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+// SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT
+// LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE,
+// DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY
+// THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package com.google.protobuf.util;
+
+import com.google.protobuf.Struct;
+import com.google.protobuf.Value;
+
+/** Utilities to help create {@code google.protobuf.Struct} messages. */
+public final class Structs {
+
+  /**
+   * Returns a struct containing the key-value pair.
+   */
+  public static Struct of(String k1, Value v1) {
+    return Struct.newBuilder().putFields(k1, v1).build();
+  }
+
+  /**
+   * Returns a struct containing each of the key-value pairs.
+   *
+   * <p>Providing duplicate keys is undefined behavior.
+   */
+  public static Struct of(String k1, Value v1, String k2, Value v2) {
+    return Struct.newBuilder().putFields(k1, v1).putFields(k2, v2).build();
+  }
+
+  /**
+   * Returns a struct containing each of the key-value pairs.
+   *
+   * <p>Providing duplicate keys is undefined behavior.
+   */
+  public static Struct of(String k1, Value v1, String k2, Value v2, String k3, Value v3) {
+    return Struct.newBuilder().putFields(k1, v1).putFields(k2, v2).putFields(k3, v3).build();
+  }
+
+  private Structs() {}
+}
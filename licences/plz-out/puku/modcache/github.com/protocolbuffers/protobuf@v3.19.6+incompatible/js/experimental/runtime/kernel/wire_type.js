@@ -0,0 +1,17 @@
+goog.module('protobuf.binary.WireType');
+
+/**
+ * Wire-format type codes, taken from proto2/public/wire_format_lite.h.
+ * @enum {number}
+ */
+const WireType = {
+  VARINT: 0,
+  FIXED64: 1,
+  DELIMITED: 2,
+  START_GROUP: 3,
+  END_GROUP: 4,
+  FIXED32: 5,
+  INVALID: 6
+};
+
+exports = WireType;
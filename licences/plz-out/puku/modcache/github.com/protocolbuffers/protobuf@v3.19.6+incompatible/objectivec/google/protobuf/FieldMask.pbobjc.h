@@ -0,0 +1,2 @@
+// Moved to root of objectivec directory, shim to keep anyone's imports working.
+#import "GPBFieldMask.pbobjc.h"
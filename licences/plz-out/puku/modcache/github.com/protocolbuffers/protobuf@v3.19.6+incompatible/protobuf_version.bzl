@@ -0,0 +1 @@
+PROTOBUF_VERSION = '3.19.6'
@@ -0,0 +1,2 @@
+// Package http DEPRECATED USE net/http/httptest
+package http
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"time"
 
 	cli "github.com/peterebden/go-cli-init/v5/logging"
@@ -12,16 +13,76 @@ import (
 
 var log = logging.MustGetLogger("puku")
 
+// backend is where log messages are written. Warnings and errors are also recorded while Capture is running.
+var backend = newBackend()
+
+var (
+	captureMu sync.Mutex
+	captured  *[]string
+)
+
+func init() {
+	log.SetBackend(capturingBackend{})
+}
+
 func InitLogging(verbosity cli.Verbosity) {
-	be := logging.AddModuleLevel(logging.NewBackendFormatter(logging.NewLogBackend(os.Stderr, "", 0), formatter{}))
+	be := newBackend()
 	be.SetLevel(logging.Level(verbosity), "puku")
-	log.SetBackend(be)
+	backend = be
 }
 
 func GetLogger() *logging.Logger {
 	return log
 }
 
+// Capture calls fn, returning the warnings and errors that were logged while it ran, e.g. so the daemon can send them
+// back to the client that made the request. They're still written to stderr as usual.
+func Capture(fn func()) []string {
+	msgs := []string{}
+	captureMu.Lock()
+	captured = &msgs
+	captureMu.Unlock()
+
+	defer func() {
+		captureMu.Lock()
+		captured = nil
+		captureMu.Unlock()
+	}()
+
+	fn()
+	return msgs
+}
+
+func newBackend() logging.LeveledBackend {
+	return logging.AddModuleLevel(logging.NewBackendFormatter(logging.NewLogBackend(os.Stderr, "", 0), formatter{}))
+}
+
+// capturingBackend writes to the backend, recording any warnings and errors while Capture is running
+type capturingBackend struct{}
+
+func (capturingBackend) Log(level logging.Level, calldepth int, r *logging.Record) error {
+	if level <= logging.WARNING {
+		captureMu.Lock()
+		if captured != nil {
+			*captured = append(*captured, r.Message())
+		}
+		captureMu.Unlock()
+	}
+	return backend.Log(level, calldepth+1, r)
+}
+
+func (capturingBackend) GetLevel(module string) logging.Level {
+	return backend.GetLevel(module)
+}
+
+func (capturingBackend) SetLevel(level logging.Level, module string) {
+	backend.SetLevel(level, module)
+}
+
+func (capturingBackend) IsEnabledFor(level logging.Level, module string) bool {
+	return backend.IsEnabledFor(level, module)
+}
+
 type formatter struct {
 }
 
@@ -0,0 +1,19 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapture(t *testing.T) {
+	log.Warningf("before")
+	warnings := Capture(func() {
+		log.Infof("info")
+		log.Warningf("warning %v", 1)
+		log.Errorf("error")
+	})
+	log.Warningf("after")
+
+	assert.Equal(t, []string{"warning 1", "error"}, warnings)
+}
@@ -0,0 +1,37 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWriteMessage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeMessage(buf, &request{ID: []byte("1"), Method: "initialize", Params: []byte(`{}`)}))
+	require.NoError(t, writeMessage(buf, &request{Method: "initialized"}))
+
+	r := bufio.NewReader(buf)
+	req, err := readMessage(r)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(req.ID))
+	assert.Equal(t, "initialize", req.Method)
+
+	req, err = readMessage(r)
+	require.NoError(t, err)
+	assert.Nil(t, req.ID)
+	assert.Equal(t, "initialized", req.Method)
+}
+
+func TestRangeContains(t *testing.T) {
+	r := textRange{Start: position{Line: 2, Character: 1}, End: position{Line: 2, Character: 6}}
+
+	assert.True(t, r.contains(position{Line: 2, Character: 1}))
+	assert.True(t, r.contains(position{Line: 2, Character: 6}))
+	assert.False(t, r.contains(position{Line: 2, Character: 0}))
+	assert.False(t, r.contains(position{Line: 2, Character: 7}))
+	assert.False(t, r.contains(position{Line: 1, Character: 3}))
+}
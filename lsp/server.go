@@ -0,0 +1,450 @@
+// Package lsp implements a language server that reports the changes puku would make to build files as diagnostics on Go
+// sources, rather than rewriting the build files on disk.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/please-build/puku/generate"
+	"github.com/please-build/puku/logging"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+	"github.com/please-build/puku/version"
+)
+
+var log = logging.GetLogger()
+
+// updateCommand is the command clients can execute to update the build file for a package on disk
+const updateCommand = "puku.update"
+
+var errMethodNotFound = errors.New("method not found")
+
+// Server is a language server speaking the language server protocol over a reader and writer, usually stdin and stdout
+type Server struct {
+	in   *bufio.Reader
+	out  io.Writer
+	root string
+
+	// analyse and update analyse and update the package in a directory. These are generate.Analyse and generate.Update
+	// outside of tests.
+	analyse func(dir string) (*generate.Analysis, error)
+	update  func(dir string) error
+
+	// Packages are analysed in the background when documents are opened or saved, so we don't block the message loop.
+	// pukuMu stops analyses and updates from running at the same time, mu guards analyses, outMu stops messages from
+	// being interleaved, and refreshes tracks the background analyses so we can wait for them before exiting.
+	pukuMu    sync.Mutex
+	mu        sync.Mutex
+	outMu     sync.Mutex
+	refreshes sync.WaitGroup
+
+	analyses map[string]*generate.Analysis
+}
+
+// New creates a new language server. Paths are relative to the working directory, which should be the repo root.
+func New(in io.Reader, out io.Writer, plzConf *please.Config, opts options.Options) (*Server, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		root: root,
+		analyse: func(dir string) (*generate.Analysis, error) {
+			return generate.Analyse(plzConf, opts, dir)
+		},
+		update: func(dir string) error {
+			return generate.Update(plzConf, opts, dir)
+		},
+		analyses: map[string]*generate.Analysis{},
+	}, nil
+}
+
+// Serve handles messages from the client until it exits
+func (s *Server) Serve() error {
+	defer s.refreshes.Wait()
+	for {
+		req, err := readMessage(s.in)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(req)
+		if req.ID == nil {
+			// This is a notification, so the client isn't expecting a response
+			if err != nil {
+				log.Warningf("failed to handle %v: %v", req.Method, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			code := internalError
+			if errors.Is(err, errMethodNotFound) {
+				code = methodNotFound
+			}
+			err = s.write(&errorResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &responseError{Code: code, Message: err.Error()},
+			})
+		} else {
+			err = s.write(&response{JSONRPC: "2.0", ID: req.ID, Result: result})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return &initializeResult{
+			Capabilities: serverCapabilities{
+				// We analyse the files on disk, so we only need to know when they're opened or saved
+				TextDocumentSync:       textDocumentSyncOptions{OpenClose: true, Change: syncNone, Save: true},
+				HoverProvider:          true,
+				CodeActionProvider:     true,
+				ExecuteCommandProvider: executeCommandOptions{Commands: []string{updateCommand}},
+			},
+			ServerInfo: serverInfo{Name: "puku", Version: version.PukuVersion},
+		}, nil
+	case "initialized", "shutdown", "textDocument/didClose":
+		return nil, nil
+	case "textDocument/didOpen", "textDocument/didSave":
+		params := new(textDocumentParams)
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil, err
+		}
+		path, err := s.path(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		s.refreshInBackground(filepath.Dir(path))
+		return nil, nil
+	case "textDocument/hover":
+		params := new(textDocumentPositionParams)
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil, err
+		}
+		return s.hover(params)
+	case "textDocument/codeAction":
+		params := new(textDocumentParams)
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil, err
+		}
+		return s.codeActions(params)
+	case "workspace/executeCommand":
+		params := new(executeCommandParams)
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil, err
+		}
+		return nil, s.executeCommand(params)
+	}
+
+	if req.ID != nil {
+		return nil, fmt.Errorf("%w: %v", errMethodNotFound, req.Method)
+	}
+	return nil, nil
+}
+
+// write writes a message to the client
+func (s *Server) write(msg interface{}) error {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	return writeMessage(s.out, msg)
+}
+
+// refreshInBackground refreshes the package in the directory without blocking the message loop
+func (s *Server) refreshInBackground(dir string) {
+	s.refreshes.Add(1)
+	go func() {
+		defer s.refreshes.Done()
+		if err := s.refresh(dir); err != nil {
+			log.Warningf("failed to analyse %v: %v", dir, err)
+		}
+	}()
+}
+
+// refresh analyses the package in the directory and publishes diagnostics for its Go files
+func (s *Server) refresh(dir string) error {
+	s.pukuMu.Lock()
+	analysis, err := s.analyse(dir)
+	s.pukuMu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.analyses[dir] = analysis
+	s.mu.Unlock()
+
+	// Publish diagnostics for every Go file in the package, so we clear the diagnostics for any problems that have been
+	// fixed
+	diagnostics := map[string][]diagnostic{}
+	entries, err := os.ReadDir(filepath.Join(s.root, dir))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == ".go" {
+			diagnostics[filepath.Join(dir, entry.Name())] = []diagnostic{}
+		}
+	}
+	lines := map[string][]string{}
+	for _, i := range analysis.Imports {
+		if _, ok := lines[i.File]; !ok {
+			lines[i.File] = s.readLines(i.File)
+		}
+		if d := importDiagnostic(i, lineAt(lines[i.File], i.Pos.Line)); d != nil {
+			diagnostics[i.File] = append(diagnostics[i.File], *d)
+		}
+	}
+
+	files := make([]string, 0, len(diagnostics))
+	for file := range diagnostics {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		err := s.write(&notification{
+			JSONRPC: "2.0",
+			Method:  "textDocument/publishDiagnostics",
+			Params:  &publishDiagnosticsParams{URI: s.uri(file), Diagnostics: diagnostics[file]},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importDiagnostic returns the diagnostic for an import, or nil if there's nothing wrong with it. The line is the
+// content of the line the import is on.
+func importDiagnostic(i *generate.ImportResolution, line string) *diagnostic {
+	if i.Err != nil {
+		return &diagnostic{
+			Range:    importRange(i.Import, line),
+			Severity: severityError,
+			Source:   "puku",
+			Message:  fmt.Sprintf("couldn't resolve %q: %v", i.Path, i.Err),
+		}
+	}
+	if i.Missing {
+		return &diagnostic{
+			Range:    importRange(i.Import, line),
+			Severity: severityWarning,
+			Source:   "puku",
+			Message:  fmt.Sprintf("%v is missing the dependency %v for %q", i.Target, i.Dep, i.Path),
+		}
+	}
+	return nil
+}
+
+// importRange returns the range of the import spec in its source file. The Go parser gives us byte offsets, but LSP
+// positions are in UTF-16 code units, so we convert them using the content of the line the import is on. If we don't
+// have the line, we assume it's ASCII.
+func importRange(i *generate.Import, line string) textRange {
+	start := i.Pos.Column - 1
+	end := start + len(strconv.Quote(i.Path))
+	if i.Name != "" {
+		end += len(i.Name) + 1
+	}
+	if end <= len(line) {
+		start, end = utf16Len(line[:start]), utf16Len(line[:end])
+	}
+	return textRange{
+		Start: position{Line: i.Pos.Line - 1, Character: start},
+		End:   position{Line: i.Pos.Line - 1, Character: end},
+	}
+}
+
+// utf16Len returns the length of the string in UTF-16 code units
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// readLines returns the lines of the file, which is relative to the repo root, or nil if it can't be read
+func (s *Server) readLines(path string) []string {
+	content, err := os.ReadFile(filepath.Join(s.root, path))
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(content), "\n")
+}
+
+// lineAt returns the content of the line with the given 1-based number, or an empty string if there isn't one
+func lineAt(lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return lines[n-1]
+}
+
+// hover shows the label an import resolves to
+func (s *Server) hover(params *textDocumentPositionParams) (*hover, error) {
+	path, err := s.path(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	analysis, err := s.analysis(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := s.readLines(path)
+	for _, i := range analysis.Imports {
+		if i.File != path {
+			continue
+		}
+		r := importRange(i.Import, lineAt(lines, i.Pos.Line))
+		if !r.contains(params.Position) {
+			continue
+		}
+
+		var value string
+		switch {
+		case i.Err != nil:
+			value = fmt.Sprintf("puku couldn't resolve `%v`: %v", i.Path, i.Err)
+		case i.Dep == "":
+			value = fmt.Sprintf("`%v` doesn't need a dependency", i.Path)
+		default:
+			value = fmt.Sprintf("`%v` resolves to `%v`", i.Path, absoluteLabel(analysis.Dir, i.Dep))
+		}
+		return &hover{Contents: markupContent{Kind: "markdown", Value: value}, Range: r}, nil
+	}
+	return nil, nil
+}
+
+// absoluteLabel returns the absolute form of a label that's relative to the package in the directory
+func absoluteLabel(dir, label string) string {
+	if !strings.HasPrefix(label, ":") {
+		return label
+	}
+	if dir == "." {
+		dir = ""
+	}
+	return "//" + dir + label
+}
+
+// codeActions offers to apply the changes puku would make to the build file for the document's package
+func (s *Server) codeActions(params *textDocumentParams) ([]*codeAction, error) {
+	path, err := s.path(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(path)
+	analysis, err := s.analysis(dir)
+	if err != nil {
+		return nil, err
+	}
+	if analysis.NewBuildFile == "" {
+		return []*codeAction{}, nil
+	}
+
+	action := &codeAction{Title: "Update build file with puku", Kind: "quickfix"}
+
+	content, err := os.ReadFile(analysis.BuildFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		// We can't edit a build file that doesn't exist, so get puku to create it instead
+		action.Command = &command{Title: action.Title, Command: updateCommand, Arguments: []interface{}{dir}}
+		return []*codeAction{action}, nil
+	}
+
+	action.Edit = &workspaceEdit{
+		Changes: map[string][]textEdit{
+			s.uri(analysis.BuildFile): {{Range: wholeFile(string(content)), NewText: analysis.NewBuildFile}},
+		},
+	}
+	return []*codeAction{action}, nil
+}
+
+// wholeFile returns the range covering all of the file's content
+func wholeFile(content string) textRange {
+	lines := strings.Split(content, "\n")
+	return textRange{End: position{Line: len(lines) - 1, Character: utf16Len(lines[len(lines)-1])}}
+}
+
+// executeCommand updates the build file for a package on disk
+func (s *Server) executeCommand(params *executeCommandParams) error {
+	if params.Command != updateCommand {
+		return fmt.Errorf("%w: unknown command %v", errMethodNotFound, params.Command)
+	}
+	if len(params.Arguments) != 1 {
+		return fmt.Errorf("%v takes the package directory as its only argument", updateCommand)
+	}
+
+	var dir string
+	if err := json.Unmarshal(params.Arguments[0], &dir); err != nil {
+		return err
+	}
+	s.pukuMu.Lock()
+	err := s.update(dir)
+	s.pukuMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.refresh(dir)
+}
+
+// analysis returns the analysis for the package in the directory, analysing it if we haven't already
+func (s *Server) analysis(dir string) (*generate.Analysis, error) {
+	s.mu.Lock()
+	analysis, ok := s.analyses[dir]
+	s.mu.Unlock()
+	if ok {
+		return analysis, nil
+	}
+	if err := s.refresh(dir); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.analyses[dir], nil
+}
+
+// path returns the path of the file with the given URI, relative to the repo root
+func (s *Server) path(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI %v", uri)
+	}
+	return filepath.Rel(s.root, u.Path)
+}
+
+// uri returns the URI for the path, which is relative to the repo root
+func (s *Server) uri(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.Join(s.root, path)}).String()
+}
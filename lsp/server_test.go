@@ -0,0 +1,241 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/generate"
+)
+
+func newTestServer(t *testing.T, in *bytes.Buffer, analysis *generate.Analysis) (*Server, *bytes.Buffer) {
+	t.Helper()
+
+	out := new(bytes.Buffer)
+	return &Server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		root: t.TempDir(),
+		analyse: func(string) (*generate.Analysis, error) {
+			return analysis, nil
+		},
+		update: func(string) error {
+			return nil
+		},
+		analyses: map[string]*generate.Analysis{},
+	}, out
+}
+
+func TestServe(t *testing.T) {
+	in := new(bytes.Buffer)
+	require.NoError(t, writeMessage(in, &request{ID: []byte("1"), Method: "initialize", Params: []byte(`{}`)}))
+	require.NoError(t, writeMessage(in, &request{Method: "initialized"}))
+	require.NoError(t, writeMessage(in, &request{ID: []byte("2"), Method: "textDocument/definition"}))
+	require.NoError(t, writeMessage(in, &request{ID: []byte("3"), Method: "shutdown"}))
+	require.NoError(t, writeMessage(in, &request{Method: "exit"}))
+
+	s, out := newTestServer(t, in, nil)
+	require.NoError(t, s.Serve())
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(stripHeaders(t, out.Bytes())))
+	for dec.More() {
+		resp := map[string]interface{}{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	require.Len(t, responses, 3)
+
+	result := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, "puku", result["serverInfo"].(map[string]interface{})["name"])
+	assert.Equal(t, true, result["capabilities"].(map[string]interface{})["hoverProvider"])
+
+	assert.Equal(t, float64(methodNotFound), responses[1]["error"].(map[string]interface{})["code"])
+
+	assert.Equal(t, float64(3), responses[2]["id"])
+	assert.Contains(t, responses[2], "result")
+	assert.Nil(t, responses[2]["result"])
+}
+
+// notifyingWriter closes a channel the first time something is written to it
+type notifyingWriter struct {
+	bytes.Buffer
+	written chan struct{}
+}
+
+func (w *notifyingWriter) Write(p []byte) (int, error) {
+	if w.Len() == 0 {
+		close(w.written)
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestAnalysesInBackground(t *testing.T) {
+	in := new(bytes.Buffer)
+	s, _ := newTestServer(t, in, nil)
+	require.NoError(t, os.MkdirAll(filepath.Join(s.root, "foo"), os.ModePerm))
+
+	params, err := json.Marshal(&textDocumentParams{TextDocument: textDocumentIdentifier{URI: s.uri("foo/foo.go")}})
+	require.NoError(t, err)
+	require.NoError(t, writeMessage(in, &request{Method: "textDocument/didOpen", Params: params}))
+	require.NoError(t, writeMessage(in, &request{ID: []byte("1"), Method: "shutdown"}))
+	require.NoError(t, writeMessage(in, &request{Method: "exit"}))
+
+	out := &notifyingWriter{written: make(chan struct{})}
+	s.out = out
+	s.analyse = func(string) (*generate.Analysis, error) {
+		// If the analysis blocked the message loop, we'd never respond to the shutdown request
+		select {
+		case <-out.written:
+		case <-time.After(10 * time.Second):
+			t.Error("analysis blocked the message loop")
+		}
+		return &generate.Analysis{Dir: "foo"}, nil
+	}
+	require.NoError(t, s.Serve())
+
+	resp := map[string]interface{}{}
+	require.NoError(t, json.NewDecoder(bytes.NewReader(stripHeaders(t, out.Bytes()))).Decode(&resp))
+	assert.Equal(t, float64(1), resp["id"])
+	assert.Contains(t, s.analyses, "foo")
+}
+
+// stripHeaders removes the headers from the messages the server wrote, leaving a stream of JSON values
+func stripHeaders(t *testing.T, out []byte) []byte {
+	t.Helper()
+
+	var body []byte
+	for len(out) > 0 {
+		i := bytes.Index(out, []byte("\r\n\r\n"))
+		require.NotEqual(t, -1, i)
+		out = out[i+4:]
+
+		next := bytes.Index(out, []byte("Content-Length:"))
+		if next == -1 {
+			next = len(out)
+		}
+		body = append(body, out[:next]...)
+		out = out[next:]
+	}
+	return body
+}
+
+func TestRefresh(t *testing.T) {
+	analysis := &generate.Analysis{
+		Dir: "foo",
+		Imports: []*generate.ImportResolution{
+			{
+				Import:  &generate.Import{Path: "github.com/example/missing", Pos: token.Position{Line: 4, Column: 2}},
+				File:    "foo/foo.go",
+				Target:  "//foo:foo",
+				Dep:     "//bar",
+				Missing: true,
+			},
+			{
+				Import: &generate.Import{Name: "b", Path: "github.com/example/broken", Pos: token.Position{Line: 5, Column: 2}},
+				File:   "foo/foo.go",
+				Target: "//foo:foo",
+				Err:    errors.New("not found"),
+			},
+			{
+				Import: &generate.Import{Path: "github.com/example/fine", Pos: token.Position{Line: 6, Column: 2}},
+				File:   "foo/foo.go",
+				Target: "//foo:foo",
+				Dep:    "//fine",
+			},
+		},
+	}
+	s, out := newTestServer(t, new(bytes.Buffer), analysis)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(s.root, "foo"), os.ModePerm))
+	for _, name := range []string{"foo.go", "foo_test.go", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(s.root, "foo", name), nil, 0644))
+	}
+
+	require.NoError(t, s.refresh("foo"))
+
+	var published []*publishDiagnosticsParams
+	dec := json.NewDecoder(bytes.NewReader(stripHeaders(t, out.Bytes())))
+	for dec.More() {
+		n := struct{ Params *publishDiagnosticsParams }{}
+		require.NoError(t, dec.Decode(&n))
+		published = append(published, n.Params)
+	}
+
+	// Diagnostics are published for every Go file so problems that have been fixed are cleared
+	require.Len(t, published, 2)
+	assert.Equal(t, s.uri("foo/foo.go"), published[0].URI)
+	assert.Equal(t, s.uri("foo/foo_test.go"), published[1].URI)
+	assert.Empty(t, published[1].Diagnostics)
+
+	require.Len(t, published[0].Diagnostics, 2)
+	missing := published[0].Diagnostics[0]
+	assert.Equal(t, severityWarning, missing.Severity)
+	assert.Equal(t, textRange{Start: position{Line: 3, Character: 1}, End: position{Line: 3, Character: 29}}, missing.Range)
+	assert.Equal(t, `//foo:foo is missing the dependency //bar for "github.com/example/missing"`, missing.Message)
+
+	broken := published[0].Diagnostics[1]
+	assert.Equal(t, severityError, broken.Severity)
+	assert.Equal(t, textRange{Start: position{Line: 4, Character: 1}, End: position{Line: 4, Character: 30}}, broken.Range)
+	assert.Equal(t, `couldn't resolve "github.com/example/broken": not found`, broken.Message)
+}
+
+func TestHover(t *testing.T) {
+	analysis := &generate.Analysis{
+		Dir: "foo",
+		Imports: []*generate.ImportResolution{
+			{
+				Import: &generate.Import{Path: "github.com/example/bar", Pos: token.Position{Line: 4, Column: 2}},
+				File:   "foo/foo.go",
+				Target: "//foo:foo",
+				Dep:    ":bar",
+			},
+		},
+	}
+	s, _ := newTestServer(t, new(bytes.Buffer), analysis)
+	s.analyses["foo"] = analysis
+
+	h, err := s.hover(&textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: s.uri("foo/foo.go")},
+		Position:     position{Line: 3, Character: 10},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.Equal(t, "`github.com/example/bar` resolves to `//foo:bar`", h.Contents.Value)
+
+	h, err = s.hover(&textDocumentPositionParams{
+		TextDocument: textDocumentIdentifier{URI: s.uri("foo/foo.go")},
+		Position:     position{Line: 8, Character: 10},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, h)
+}
+
+func TestWholeFile(t *testing.T) {
+	assert.Equal(t, textRange{End: position{Line: 2, Character: 0}}, wholeFile("foo\nbar\n"))
+	assert.Equal(t, textRange{End: position{Line: 1, Character: 3}}, wholeFile("foo\nbar"))
+	assert.Equal(t, textRange{End: position{Line: 1, Character: 5}}, wholeFile("foo\n\"é😀\""))
+}
+
+func TestImportRange(t *testing.T) {
+	i := &generate.Import{Name: "b", Path: "github.com/example/bar", Pos: token.Position{Line: 3, Column: 14}}
+
+	// The Go parser gives us byte columns, but LSP uses UTF-16 code units. é is 2 bytes but 1 code unit, and 😀 is 4
+	// bytes but 2 code units.
+	line := "/* é😀 */ " + `b "github.com/example/bar"`
+	r := importRange(i, line)
+	assert.Equal(t, textRange{Start: position{Line: 2, Character: 10}, End: position{Line: 2, Character: 36}}, r)
+
+	// Without the line, we assume it's ASCII
+	r = importRange(i, "")
+	assert.Equal(t, textRange{Start: position{Line: 2, Character: 13}, End: position{Line: 2, Character: 39}}, r)
+}
@@ -2,6 +2,8 @@ package please
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -15,48 +17,87 @@ func Build(plz string, targets ...string) ([]string, error) {
 	return strings.Split(strings.TrimSpace(string(out)), "\n"), nil
 }
 
-// RecursivelyProvide queries the target, checking if it provides a different target for the given requirement, and
-// returns that, repeating the operation if that target also provides a different target.
-func RecursivelyProvide(plz string, target string, requires string) ([]string, error) {
-	out, err := execPlease(plz, "query", "print", "--json", "--field=provides", target)
+// TargetInfo is what puku needs to know about a target to use its outputs as sources
+type TargetInfo struct {
+	// Provides maps requirements to the targets this target provides for them
+	Provides map[string][]string
+	// Outs are the names of the outputs of the target, relative to its package
+	Outs []string
+}
+
+// QueryTargets queries the provides and outs of several targets with a single plz invocation. Targets that plz doesn't
+// return any fields for are missing from the result.
+func QueryTargets(plz string, targets ...string) (map[string]*TargetInfo, error) {
+	out, err := execPlease(plz, append([]string{"query", "print", "--json", "--field=provides", "--field=outs"}, targets...)...)
 	if err != nil {
 		return nil, err
 	}
-	// Returns a map of labels to fields. One of the fields in provides, which is a map of requests to targets, so we
-	// index res[target]["provides"][requires] to get the provided target
-	res := map[string]map[string]map[string][]string{}
+	res := map[string]struct {
+		Provides map[string][]string `json:"provides"`
+		Outs     json.RawMessage     `json:"outs"`
+	}{}
 	if err := json.Unmarshal(out, &res); err != nil {
 		return nil, err
 	}
 
-	t, ok := res[target]
-	if !ok {
-		return []string{target}, nil
+	ret := make(map[string]*TargetInfo, len(res))
+	for target, fields := range res {
+		outs, err := parseStrings(fields.Outs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse outs of %v: %w", target, err)
+		}
+		ret[target] = &TargetInfo{Provides: fields.Provides, Outs: outs}
 	}
+	return ret, nil
+}
 
-	provides, ok := t["provides"]
-	if !ok {
-		return []string{target}, nil
+// QuerySources queries the srcs of several targets with a single plz invocation. Targets can be pseudo-targets like
+// //foo:all, in which case the result contains the srcs of each of the targets in the package.
+func QuerySources(plz string, targets ...string) (map[string][]string, error) {
+	out, err := execPlease(plz, append([]string{"query", "print", "--json", "--field=srcs"}, targets...)...)
+	if err != nil {
+		return nil, err
 	}
-
-	providedTargets := provides[requires]
-
-	if len(providedTargets) == 0 || (len(providedTargets) == 1 && providedTargets[0] == target) {
-		return []string{target}, nil
+	res := map[string]struct {
+		Srcs json.RawMessage `json:"srcs"`
+	}{}
+	if err := json.Unmarshal(out, &res); err != nil {
+		return nil, err
 	}
 
-	ret := make([]string, 0, len(providedTargets))
-	for _, providedTarget := range providedTargets {
-		if providedTarget == target {
-			ret = append(ret, providedTarget) // Providing itself, don't recurse
-		} else {
-			r, err := RecursivelyProvide(plz, providedTarget, requires)
-			if err != nil {
-				return []string{}, err
-			}
-			ret = append(ret, r...)
+	ret := make(map[string][]string, len(res))
+	for target, fields := range res {
+		srcs, err := parseStrings(fields.Srcs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse srcs of %v: %w", target, err)
 		}
+		ret[target] = srcs
 	}
-
 	return ret, nil
 }
+
+// parseStrings parses a list of strings from a target, e.g. its outs or srcs. These are a list, or a map of lists for
+// targets with named outputs or sources.
+func parseStrings(data json.RawMessage) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var outs []string
+	if err := json.Unmarshal(data, &outs); err == nil {
+		return outs, nil
+	}
+
+	named := map[string][]string{}
+	if err := json.Unmarshal(data, &named); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		outs = append(outs, named[name]...)
+	}
+	return outs, nil
+}
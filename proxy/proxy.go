@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	"golang.org/x/mod/semver"
 )
 
@@ -37,14 +38,26 @@ type Module struct {
 type Proxy struct {
 	latestVer map[string]Module
 	modFiles  map[Module]*modfile.File
+	files     map[Module][]string
 	url       string
+
+	// filesCacheDir is where we cache the list of files in each module version, as these never change. This is empty
+	// if there's no cache directory.
+	filesCacheDir string
 }
 
 func New(url string) *Proxy {
+	var filesCacheDir string
+	if dir, err := os.UserCacheDir(); err == nil {
+		filesCacheDir = filepath.Join(dir, "puku", "files")
+	}
+
 	return &Proxy{
-		latestVer: map[string]Module{},
-		modFiles:  map[Module]*modfile.File{},
-		url:       url,
+		latestVer:     map[string]Module{},
+		modFiles:      map[Module]*modfile.File{},
+		files:         map[Module][]string{},
+		url:           url,
+		filesCacheDir: filesCacheDir,
 	}
 }
 
@@ -92,9 +105,13 @@ func (proxy *Proxy) GetLatestVersion(modulePath string) (Module, error) {
 	return proxy.latestVer[modulePath], nil
 }
 
-// ResolveModuleForPackage tries to determine the module name for a given package pattern
+// ResolveModuleForPackage tries to determine the module name for a given package pattern. If the pattern is a single
+// package, rather than a wildcard, the files in the module are checked to make sure it actually contains that package.
+// If it doesn't, we carry on looking at the parent paths, and if the package is part of a nested module, that module is
+// returned instead.
 func (proxy *Proxy) ResolveModuleForPackage(pattern string) (*Module, error) {
 	modulePath := strings.TrimSuffix(pattern, "/...")
+	isWildcard := modulePath != pattern
 
 	var paths []string
 	for modulePath != "." {
@@ -102,6 +119,21 @@ func (proxy *Proxy) ResolveModuleForPackage(pattern string) (*Module, error) {
 		// Try and get the latest version to determine if we've found the module part yet
 		latest, err := proxy.GetLatestVersion(modulePath)
 		if err == nil {
+			// If the module path is different, we've already resolved this path to a module, but that might have been
+			// for a different package, so we still need to check it contains this one.
+			if !isWildcard {
+				mod, err := proxy.moduleContainingPackage(latest, pattern)
+				if err != nil {
+					return nil, err
+				}
+				if mod == nil {
+					// This is a module, but not the one we're looking for, so don't cache the package against it
+					paths = paths[:len(paths)-1]
+					modulePath = filepath.Dir(modulePath)
+					continue
+				}
+				latest = *mod
+			}
 			for _, p := range paths {
 				proxy.latestVer[p] = latest
 			}
@@ -113,7 +145,154 @@ func (proxy *Proxy) ResolveModuleForPackage(pattern string) (*Module, error) {
 
 		modulePath = filepath.Dir(modulePath)
 	}
-	return nil, ModuleNotFound{Path: modulePath}
+	return nil, ModuleNotFound{Path: pattern}
+}
+
+// moduleContainingPackage checks the files in the module to find the module that contains the package. This will
+// return the module itself if the package directory contains .go files, or the nested module if the package is under a
+// directory with its own go.mod. Returns nil if the package couldn't be found in the module.
+func (proxy *Proxy) moduleContainingPackage(mod Module, pkg string) (*Module, error) {
+	files, err := proxy.getFiles(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgDir := strings.TrimPrefix(strings.TrimPrefix(pkg, mod.Module), "/")
+	if pkgDir == "" {
+		pkgDir = "."
+	}
+
+	fileSet := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		fileSet[f] = struct{}{}
+	}
+
+	// The module proxy strips out nested modules from the zip, however other proxies might not, so check for a go.mod
+	// in the package directory, or any of its parents below the module root.
+	for dir := pkgDir; dir != "."; dir = filepath.Dir(dir) {
+		if _, ok := fileSet[filepath.Join(dir, "go.mod")]; ok {
+			nested, err := proxy.GetLatestVersion(filepath.Join(mod.Module, dir))
+			if err != nil {
+				return nil, err
+			}
+			return &nested, nil
+		}
+	}
+
+	for _, f := range files {
+		if filepath.Dir(f) == pkgDir && filepath.Ext(f) == ".go" {
+			return &mod, nil
+		}
+	}
+	return nil, nil
+}
+
+// getFiles returns the paths of the files in a module, relative to the module root, based on the module zip. The list
+// is cached on disk, as module versions are immutable, so we only download each zip once.
+func (proxy *Proxy) getFiles(mod Module) ([]string, error) {
+	if files, ok := proxy.files[mod]; ok {
+		return files, nil
+	}
+
+	escapedPath, err := module.EscapePath(mod.Module)
+	if err != nil {
+		return nil, err
+	}
+	escapedVersion, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var cacheFile string
+	if proxy.filesCacheDir != "" {
+		cacheFile = filepath.Join(proxy.filesCacheDir, escapedPath, "@v", escapedVersion+".files")
+		if bs, err := os.ReadFile(cacheFile); err == nil {
+			files := strings.Split(strings.TrimSuffix(string(bs), "\n"), "\n")
+			proxy.files[mod] = files
+			return files, nil
+		}
+	}
+
+	files, err := proxy.downloadFiles(mod, fmt.Sprintf("%s/%s/@v/%s.zip", proxy.url, escapedPath, escapedVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheFile != "" {
+		// The cache is only an optimisation, so carry on if we can't write to it
+		_ = writeCacheFile(cacheFile, []byte(strings.Join(files, "\n")+"\n"))
+	}
+	proxy.files[mod] = files
+	return files, nil
+}
+
+// writeCacheFile writes to a temporary file and then moves it into place, so other puku processes sharing the cache never
+// read a partially written file
+func writeCacheFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// downloadFiles downloads the module zip to a temporary file, and returns the paths of the files in it. Module zips can
+// be hundreds of megabytes, so we avoid reading them into memory.
+func (proxy *Proxy) downloadFiles(mod Module, url string) ([]string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			return nil, ModuleNotFound{Path: mod.Module}
+		}
+		return nil, fmt.Errorf("unexpected status code getting module zip %v: %v", url, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "puku-module-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the central directory at the end of the zip is read to list the files
+	zipReader, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files in the module zip are prefixed with module@version
+	prefix := fmt.Sprintf("%s@%s/", mod.Module, mod.Version)
+	files := make([]string, 0, len(zipReader.File))
+	for _, zipFile := range zipReader.File {
+		files = append(files, strings.TrimPrefix(zipFile.Name, prefix))
+	}
+	return files, nil
 }
 
 // getGoModWithFallback attempts to get a go.mod for the given module and
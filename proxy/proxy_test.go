@@ -0,0 +1,162 @@
+package proxy
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+)
+
+// newFakeProxy returns a proxy server serving the given modules. Modules are a map of module path to the files they
+// contain.
+func newFakeProxy(t *testing.T, modules map[string][]string) *Proxy {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if mod, ok := strings.CutSuffix(path, "/@latest"); ok {
+			// Puku lower cases the paths it requests the latest version of
+			for m := range modules {
+				if strings.EqualFold(m, mod) {
+					fmt.Fprint(w, `{"Version": "v1.0.0"}`)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		escaped, _, _ := strings.Cut(path, "/@v/")
+		mod, err := module.UnescapePath(escaped)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		files, ok := modules[mod]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		buf := new(bytes.Buffer)
+		zw := zip.NewWriter(buf)
+		for _, f := range files {
+			_, err := zw.Create(fmt.Sprintf("%s@v1.0.0/%s", mod, f))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		_, err = w.Write(buf.Bytes())
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	p := New(server.URL)
+	p.filesCacheDir = t.TempDir()
+	return p
+}
+
+func TestResolveModuleForPackage(t *testing.T) {
+	p := newFakeProxy(t, map[string][]string{
+		"example.com/mod":     {"go.mod", "mod.go", "foo/foo.go", "foo/bar/bar.go", "sub/pkg/pkg.go"},
+		"example.com/mod/sub": {"go.mod", "sub.go"},
+	})
+
+	t.Run("resolves package in module", func(t *testing.T) {
+		mod, err := p.ResolveModuleForPackage("example.com/mod/foo")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod", mod.Module)
+	})
+
+	t.Run("resolves module root package", func(t *testing.T) {
+		mod, err := p.ResolveModuleForPackage("example.com/mod")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod", mod.Module)
+	})
+
+	t.Run("skips modules that don't contain the package", func(t *testing.T) {
+		mod, err := p.ResolveModuleForPackage("example.com/mod/sub/pkg")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod", mod.Module)
+
+		// We shouldn't have cached the nested module as belonging to the parent module
+		mod, err = p.ResolveModuleForPackage("example.com/mod/sub")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod/sub", mod.Module)
+	})
+
+	t.Run("checks packages under paths that were resolved for other packages", func(t *testing.T) {
+		mod, err := p.ResolveModuleForPackage("example.com/mod/foo/bar")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod", mod.Module)
+
+		// example.com/mod/foo is now cached against the module, but that doesn't mean it contains this package
+		_, err = p.ResolveModuleForPackage("example.com/mod/foo/missing")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("doesn't check the files for wildcards", func(t *testing.T) {
+		mod, err := p.ResolveModuleForPackage("example.com/mod/sub/...")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/mod/sub", mod.Module)
+	})
+
+	t.Run("errors when no module contains the package", func(t *testing.T) {
+		_, err := p.ResolveModuleForPackage("example.com/mod/missing")
+		assert.True(t, IsNotFound(err))
+	})
+}
+
+func TestResolveModuleForPackageInNestedModule(t *testing.T) {
+	p := newFakeProxy(t, map[string][]string{
+		"example.com/mod":        {"go.mod", "mod.go", "nested/go.mod", "nested/pkg/pkg.go"},
+		"example.com/mod/nested": {"go.mod", "pkg/pkg.go"},
+	})
+
+	// The module proxy strips nested modules out of the zip, but other proxies might not
+	mod, err := p.moduleContainingPackage(Module{Module: "example.com/mod", Version: "v1.0.0"}, "example.com/mod/nested/pkg")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/mod/nested", mod.Module)
+}
+
+func TestResolveModuleForPackageWithCapitalLetters(t *testing.T) {
+	p := newFakeProxy(t, map[string][]string{
+		"example.com/BurntSushi/mod": {"go.mod", "mod.go", "foo/foo.go"},
+	})
+
+	mod, err := p.ResolveModuleForPackage("example.com/BurntSushi/mod/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/BurntSushi/mod", mod.Module)
+}
+
+func TestGetFilesCachesOnDisk(t *testing.T) {
+	p := newFakeProxy(t, map[string][]string{
+		"example.com/mod": {"go.mod", "mod.go", "foo/foo.go"},
+	})
+	mod := Module{Module: "example.com/mod", Version: "v1.0.0"}
+
+	files, err := p.getFiles(mod)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.mod", "mod.go", "foo/foo.go"}, files)
+
+	// The cache file is written to a temporary file and moved into place, which shouldn't be left behind
+	entries, err := os.ReadDir(filepath.Join(p.filesCacheDir, "example.com", "mod", "@v"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "v1.0.0.files", entries[0].Name())
+
+	// A new proxy shouldn't need to download the zip again
+	cached := New("http://localhost:0")
+	cached.filesCacheDir = p.filesCacheDir
+	files, err = cached.getFiles(mod)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.mod", "mod.go", "foo/foo.go"}, files)
+}
@@ -0,0 +1,110 @@
+package watch
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/please-build/puku/config"
+	"github.com/please-build/puku/generate"
+	"github.com/please-build/puku/graph"
+	"github.com/please-build/puku/kinds"
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+// importIndex is a reverse index of the imports of the packages we're watching. This lets us update the packages that
+// import a package when the targets they depend on for it change, e.g. when its library is renamed.
+type importIndex struct {
+	config *please.Config
+	opts   options.Options
+
+	// importers are the directories of the packages that import each import path
+	importers map[string]map[string]struct{}
+	// imports are the import paths imported by the package in each directory
+	imports map[string][]string
+	// exports describes what the package in each directory provides to packages that import it, i.e. the names of its
+	// libraries and Go packages. When this changes, the packages that import it need updating.
+	exports map[string]string
+}
+
+func newImportIndex(config *please.Config, opts options.Options) *importIndex {
+	return &importIndex{
+		config:    config,
+		opts:      opts,
+		importers: map[string]map[string]struct{}{},
+		imports:   map[string][]string{},
+		exports:   map[string]string{},
+	}
+}
+
+// update re-indexes the package in a directory, returning whether what it exports has changed
+func (idx *importIndex) update(dir string) (bool, error) {
+	files, err := generate.ImportDir(dir)
+	if err != nil {
+		// The directory has been removed, so nothing can import it anymore
+		files = map[string]*generate.GoFile{}
+	}
+
+	for _, i := range idx.imports[dir] {
+		delete(idx.importers[i], dir)
+	}
+	var imports []string
+	for _, f := range files {
+		for _, i := range f.Imports {
+			if idx.importers[i] == nil {
+				idx.importers[i] = map[string]struct{}{}
+			}
+			idx.importers[i][dir] = struct{}{}
+			imports = append(imports, i)
+		}
+	}
+	idx.imports[dir] = imports
+
+	exports, err := idx.packageExports(dir, files)
+	if err != nil {
+		return false, err
+	}
+	old, indexed := idx.exports[dir]
+	idx.exports[dir] = exports
+	return indexed && old != exports, nil
+}
+
+// packageExports returns a description of the libraries and Go packages in a directory
+func (idx *importIndex) packageExports(dir string, files map[string]*generate.GoFile) (string, error) {
+	var exports []string
+	for _, f := range files {
+		if !f.IsTest() {
+			exports = append(exports, "package "+f.Name)
+		}
+	}
+
+	conf, err := config.ReadConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	file, err := graph.New(idx.config.BuildFileNames(), idx.opts).LoadFile(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, rule := range file.Rules("") {
+		if kind := conf.GetKind(rule.Kind()); kind != nil && kind.Type == kinds.Lib {
+			exports = append(exports, "library "+rule.Name())
+		}
+	}
+
+	sort.Strings(exports)
+	return strings.Join(exports, "\n"), nil
+}
+
+// importersOf returns the directories of the packages that import the package in a directory
+func (idx *importIndex) importersOf(dir string) []string {
+	importPath := path.Join(idx.config.ImportPath(), dir)
+
+	ret := make([]string, 0, len(idx.importers[importPath]))
+	for importer := range idx.importers[importPath] {
+		ret = append(ret, importer)
+	}
+	sort.Strings(ret)
+	return ret
+}
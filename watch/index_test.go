@@ -0,0 +1,65 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/please-build/puku/options"
+	"github.com/please-build/puku/please"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestImportIndex(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	writeFile(t, "foo/foo.go", "package foo\n")
+	writeFile(t, "foo/BUILD", "go_library(\n    name = \"foo\",\n    srcs = [\"foo.go\"],\n)\n")
+	writeFile(t, "bar/bar.go", "package bar\n\nimport _ \"example.com/foo\"\n")
+
+	conf := new(please.Config)
+	conf.Plugin.Go.ImportPath = []string{"example.com"}
+	conf.Parse.BuildFileName = []string{"BUILD"}
+	idx := newImportIndex(conf, options.TestOptions)
+
+	for _, dir := range []string{"foo", "bar"} {
+		changed, err := idx.update(dir)
+		require.NoError(t, err)
+		assert.False(t, changed, "the first time a package is indexed isn't a change")
+	}
+	assert.Equal(t, []string{"bar"}, idx.importersOf("foo"))
+	assert.Empty(t, idx.importersOf("bar"))
+
+	changed, err := idx.update("foo")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Renaming the library means importers need to depend on the new target
+	writeFile(t, "foo/BUILD", "go_library(\n    name = \"lib\",\n    srcs = [\"foo.go\"],\n)\n")
+	changed, err = idx.update("foo")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	// As does changing the package clause
+	writeFile(t, "foo/foo.go", "package lib\n")
+	changed, err = idx.update("foo")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	// Importers are removed from the index when they stop importing the package
+	writeFile(t, "bar/bar.go", "package bar\n")
+	_, err = idx.update("bar")
+	require.NoError(t, err)
+	assert.Empty(t, idx.importersOf("foo"))
+}
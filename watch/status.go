@@ -0,0 +1,78 @@
+package watch
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+)
+
+// The states watch mode can be in
+const (
+	stateIdle     = "idle"
+	statePending  = "pending"
+	stateUpdating = "updating"
+)
+
+// Status is the state of watch mode. This is written to the status file, if there is one, so tools like editor
+// plugins can show whether the build files are up to date.
+type Status struct {
+	// State is idle, pending when there are changes waiting to be updated, or updating
+	State string
+	// Pending are the package directories waiting to be updated
+	Pending []string
+	// LastRun is the result of the last update, or nil if there hasn't been one yet
+	LastRun *Run
+}
+
+// Run is the result of updating a batch of changes
+type Run struct {
+	Time time.Time
+	// Paths are the package directories that were updated
+	Paths []string
+	// Warnings are the issues puku found but couldn't fix itself
+	Warnings []string
+	// Errors are the errors that stopped puku from updating the build files
+	Errors []string
+}
+
+// writeStatus records the state of the debouncer, writing it to the status file if there is one. The mutex must be
+// held when calling this.
+func (d *debouncer) writeStatus(state string) {
+	d.state = state
+	if d.statusFile == "" {
+		return
+	}
+
+	pending := make([]string, 0, len(d.paths))
+	for path := range d.paths {
+		pending = append(pending, path)
+	}
+	status := &Status{
+		State:   state,
+		Pending: sorted(pending),
+		LastRun: d.lastRun,
+	}
+	if err := writeStatusFile(d.statusFile, status); err != nil {
+		log.Warningf("failed to write status file: %v", err)
+	}
+}
+
+// writeStatusFile writes the status to a temporary file, then moves it into place, so readers never see a partially
+// written file
+func writeStatusFile(path string, status *Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func sorted(paths []string) []string {
+	sort.Strings(paths)
+	return paths
+}
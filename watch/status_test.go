@@ -0,0 +1,57 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStatus(t *testing.T) {
+	statusFile := filepath.Join(t.TempDir(), "status.json")
+	d := &debouncer{
+		paths:      map[string]struct{}{"foo": {}, "bar": {}},
+		statusFile: statusFile,
+		lastRun: &Run{
+			Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Paths:    []string{"baz"},
+			Warnings: []string{"//baz:baz: import cycle: //baz:baz -> //baz:baz"},
+		},
+	}
+	d.writeStatus(statePending)
+
+	data, err := os.ReadFile(statusFile)
+	require.NoError(t, err)
+
+	status := new(Status)
+	require.NoError(t, json.Unmarshal(data, status))
+	assert.Equal(t, &Status{
+		State:   statePending,
+		Pending: []string{"bar", "foo"},
+		LastRun: d.lastRun,
+	}, status)
+
+	_, err = os.Stat(statusFile + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRunReturnsOnCancel(t *testing.T) {
+	d := &debouncer{
+		paths:      map[string]struct{}{},
+		removed:    map[string]struct{}{},
+		files:      map[string]struct{}{},
+		configDirs: map[string]struct{}{},
+		timer:      time.NewTimer(time.Hour),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nothing is pending, so this should return straight away without trying to update anything
+	d.run(ctx)
+	assert.Nil(t, d.lastRun)
+}
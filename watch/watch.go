@@ -1,18 +1,28 @@
 package watch
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 
+	"github.com/please-build/puku/config"
 	"github.com/please-build/puku/generate"
+	"github.com/please-build/puku/graph"
 	"github.com/please-build/puku/logging"
 	"github.com/please-build/puku/options"
 	"github.com/please-build/puku/please"
+	modsync "github.com/please-build/puku/sync"
+	"github.com/please-build/puku/work"
 )
 
 var log = logging.GetLogger()
@@ -22,92 +32,297 @@ const debounceDuration = 200 * time.Millisecond
 // debouncer batches up updates to paths, waiting for a debounceDuration to pass. This avoids running puku many times
 // during git checkouts etc. but it also avoids inconsistent state when files are being moved around rapidly.
 type debouncer struct {
-	paths  map[string]struct{}
-	timer  *time.Timer
-	mux    sync.Mutex
-	config *please.Config
-	opts   options.Options
+	paths map[string]struct{}
+	// removed are the package directories that have been removed
+	removed map[string]struct{}
+	// files are the files that have changed, which the session needs to forget about
+	files map[string]struct{}
+	// configDirs are the directories where a puku.json has changed, and so need their configs reloading
+	configDirs map[string]struct{}
+	// sync is whether the go.mod has changed, and so the third party rules need syncing
+	sync bool
+	// index is used to find the packages that need updating when the packages they import change
+	index *importIndex
+	// session keeps what puku has loaded between updates, so we don't have to start from scratch each time
+	session *generate.Session
+	timer   *time.Timer
+	mux     sync.Mutex
+	config  *please.Config
+	opts    options.Options
+
+	// state is the state reported in the status file, which is written to statusFile if it's not empty
+	state      string
+	statusFile string
+	// lastRun is the result of the last time we updated a batch
+	lastRun *Run
+}
+
+// updateFile adds the package a changed file is in to the batch
+func (d *debouncer) updateFile(file string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.files[file] = struct{}{}
+	d.paths[filepath.Dir(file)] = struct{}{}
+	d.reset()
 }
 
-// updatePath adds a path to the batch and resets the timer to the deboundDuration
-func (d *debouncer) updatePath(path string) {
+// updatePaths adds several paths to the batch
+func (d *debouncer) updatePaths(paths []string) {
 	d.mux.Lock()
 	defer d.mux.Unlock()
 
-	d.paths[path] = struct{}{}
-	if d.timer != nil {
-		d.timer.Stop()
-		d.timer.Reset(debounceDuration)
-	} else {
-		d.timer = time.NewTimer(debounceDuration)
-		go d.wait()
+	for _, path := range paths {
+		d.paths[path] = struct{}{}
+		// The directory may have been removed and recreated within the batch
+		delete(d.removed, path)
 	}
+	d.reset()
 }
 
-// wait waits for the timer to fire before updating the paths
-func (d *debouncer) wait() {
-	<-d.timer.C
+// removePaths records that package directories have been removed, so the packages that import them can be updated
+func (d *debouncer) removePaths(paths []string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	for _, path := range paths {
+		d.removed[path] = struct{}{}
+	}
+	d.reset()
+}
 
+// updateConfig reloads the configs under the directory of a changed puku.json, and adds the paths affected by the
+// change to the batch
+func (d *debouncer) updateConfig(file string, paths []string) {
 	d.mux.Lock()
+	defer d.mux.Unlock()
 
-	paths := make([]string, 0, len(d.paths))
+	d.files[file] = struct{}{}
+	d.configDirs[filepath.Dir(file)] = struct{}{}
+	for _, path := range paths {
+		d.paths[path] = struct{}{}
+	}
+	d.reset()
+}
+
+// syncModFile syncs the third party rules with the go.mod before updating the batch
+func (d *debouncer) syncModFile() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.sync = true
+	d.reset()
+}
+
+// reset resets the timer to the debounceDuration. The mutex must be held when calling this.
+func (d *debouncer) reset() {
+	d.timer.Reset(debounceDuration)
+	// Avoid rewriting the status file for every event, e.g. during git checkouts
+	if d.state != statePending {
+		d.writeStatus(statePending)
+	}
+}
+
+// run updates the batch each time the timer fires, until the context is cancelled. Any pending updates are flushed
+// before returning.
+func (d *debouncer) run(ctx context.Context) {
+	for {
+		select {
+		case <-d.timer.C:
+			d.flush()
+		case <-ctx.Done():
+			d.timer.Stop()
+			d.flush()
+			return
+		}
+	}
+}
+
+// flush updates the paths in the batch
+func (d *debouncer) flush() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if len(d.paths) == 0 && len(d.removed) == 0 && len(d.files) == 0 && len(d.configDirs) == 0 && !d.sync {
+		return
+	}
+	d.writeStatus(stateUpdating)
+
+	// Invalidate the configs here rather than as the events come in, so they can't change part way through an update
+	for dir := range d.configDirs {
+		config.InvalidateConfigs(dir)
+	}
+
+	var errs []string
+	// Sync before updating the paths, so they're updated against the new third party rules
+	if d.sync {
+		if err := modsync.Sync(d.config, graph.New(d.config.BuildFileNames(), d.opts)); err != nil {
+			log.Warningf("failed to sync go.mod: %v", err)
+			errs = append(errs, fmt.Sprintf("failed to sync go.mod: %v", err))
+		} else {
+			log.Infof("Synced go.mod")
+		}
+		d.session.Invalidate("go.mod")
+	}
+	for file := range d.files {
+		d.session.Invalidate(file)
+	}
+
+	removed := make([]string, 0, len(d.removed))
+	for p := range d.removed {
+		removed = append(removed, p)
+	}
+
+	// Packages that imported removed packages need updating. We don't update the removed packages themselves.
+	updated := map[string]struct{}{}
+	paths := d.dependents(removed, updated)
 	for p := range d.paths {
-		paths = append(paths, p)
+		if _, ok := updated[p]; !ok {
+			paths = append(paths, p)
+		}
 	}
-	if err := generate.Update(d.config, d.opts, paths...); err != nil {
-		log.Warningf("failed to update: %v", err)
-	} else {
+
+	// Updating a package can change the targets other packages need to depend on for it, so keep going until we've
+	// updated all the packages that import them
+	var updatedPaths, warnings []string
+	for len(paths) > 0 {
+		if err := d.session.Update(paths...); err != nil {
+			log.Warningf("failed to update: %v", err)
+			errs = append(errs, fmt.Sprintf("failed to update: %v", err))
+			break
+		}
 		log.Infof("Updated paths: %v ", strings.Join(paths, ", "))
+		updatedPaths = append(updatedPaths, paths...)
+		for _, issue := range d.session.Issues() {
+			warnings = append(warnings, issue.String())
+		}
+		paths = d.dependents(paths, updated)
 	}
 	d.paths = map[string]struct{}{}
-	d.mux.Unlock()
+	d.removed = map[string]struct{}{}
+	d.files = map[string]struct{}{}
+	d.configDirs = map[string]struct{}{}
+	d.sync = false
+
+	d.lastRun = &Run{
+		Time:     time.Now(),
+		Paths:    sorted(updatedPaths),
+		Warnings: warnings,
+		Errors:   errs,
+	}
+	d.writeStatus(stateIdle)
+}
+
+// dependents re-indexes the packages that have been updated, returning the packages that import any of them whose
+// targets have changed. Packages that have already been updated in this batch aren't returned again.
+func (d *debouncer) dependents(paths []string, updated map[string]struct{}) []string {
+	for _, path := range paths {
+		updated[path] = struct{}{}
+	}
 
-	//nolint:staticcheck
-	d.wait() // infinite recursive calls are a lint error but it's what we want here
+	var ret []string
+	for _, path := range paths {
+		changed, err := d.index.update(path)
+		if err != nil {
+			log.Warningf("failed to index %v: %v", path, err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+		for _, importer := range d.index.importersOf(path) {
+			if _, ok := updated[importer]; !ok {
+				updated[importer] = struct{}{}
+				ret = append(ret, importer)
+			}
+		}
+	}
+	return ret
 }
 
-func Watch(config *please.Config, opts options.Options, paths ...string) error {
+// Watch watches the paths, updating them as they change, until puku receives SIGINT or SIGTERM. If statusFile isn't
+// empty, the status of watch mode is written to it as JSON as it changes.
+func Watch(config *please.Config, opts options.Options, statusFile string, paths ...string) error {
 	if len(paths) < 1 {
 		return nil
 	}
-	watcher, err := fsnotify.NewWatcher()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fsWatcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return err
 	}
-	defer watcher.Close()
+	defer fsWatcher.Close()
 
 	d := &debouncer{
-		paths:  map[string]struct{}{},
-		config: config,
-		opts:   opts,
+		paths:      map[string]struct{}{},
+		removed:    map[string]struct{}{},
+		files:      map[string]struct{}{},
+		configDirs: map[string]struct{}{},
+		index:      newImportIndex(config, opts),
+		session:    generate.NewSession(config, opts),
+		timer:      time.NewTimer(debounceDuration),
+		config:     config,
+		opts:       opts,
+		statusFile: statusFile,
+	}
+	d.timer.Stop()
+	for _, path := range paths {
+		if _, err := d.index.update(path); err != nil {
+			return err
+		}
+	}
+
+	w := &dirWatcher{
+		watcher: fsWatcher,
+		dirs:    map[string]struct{}{},
+	}
+	for _, path := range paths {
+		w.dirs[filepath.Clean(path)] = struct{}{}
 	}
 
 	go func() {
 		for {
 			select {
-			case event, ok := <-watcher.Events:
+			case event, ok := <-fsWatcher.Events:
 				if !ok {
 					return
 				}
-				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Remove) {
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
 					break
 				}
 
-				if filepath.Ext(event.Name) == ".go" {
-					d.updatePath(filepath.Dir(event.Name))
+				// Directories that are removed, or moved away, stop being watched. If they were moved somewhere else we're
+				// watching, we'll get a create event for their new location.
+				if (event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)) && w.isPackage(event.Name) {
+					d.removePaths(w.removePackages(event.Name))
 					break
 				}
 
-				if event.Has(fsnotify.Create) {
-					if info, err := os.Lstat(event.Name); err == nil {
-						if info.IsDir() {
-							if err := add(watcher, event.Name); err != nil {
-								log.Warningf("failed to set up watcher: %v", err)
-							}
+				dir := filepath.Dir(event.Name)
+				switch base := filepath.Base(event.Name); {
+				case base == "puku.json":
+					d.updateConfig(event.Name, w.packagesUnder(dir))
+				case base == "go.mod":
+					d.syncModFile()
+				case !w.isPackage(dir):
+					// We only watch the directories above the packages for changes to puku.json and go.mod
+				case filepath.Ext(base) == ".go":
+					d.updateFile(event.Name)
+				case isBuildFile(config, base):
+					// Puku only writes build files when it changes them, so its own writes settle after one more run
+					d.updateFile(event.Name)
+				case event.Has(fsnotify.Create):
+					if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+						added, err := w.addPackages(event.Name)
+						if err != nil {
+							log.Warningf("failed to set up watcher: %v", err)
 						}
+						d.updatePaths(added)
 					}
 				}
-			case err, ok := <-watcher.Errors:
+			case err, ok := <-fsWatcher.Errors:
 				if !ok {
 					return
 				}
@@ -116,22 +331,163 @@ func Watch(config *please.Config, opts options.Options, paths ...string) error {
 		}
 	}()
 
-	if err := add(watcher, paths...); err != nil {
+	if err := w.add(paths...); err != nil {
+		return err
+	}
+	if err := w.add(parentDirs(w.dirs)...); err != nil {
 		return err
 	}
+
+	d.mux.Lock()
+	d.writeStatus(stateIdle)
+	d.mux.Unlock()
+
 	log.Info("And so my watch begins...")
-	select {}
+	// Once we've been asked to stop, this finishes any updates that were waiting for the debounce timer before returning
+	d.run(ctx)
+	log.Info("And now my watch is ended")
+
+	// Remove the status file so tools don't think we're still watching
+	if statusFile != "" {
+		if err := os.Remove(statusFile); err != nil {
+			log.Warningf("failed to remove status file: %v", err)
+		}
+	}
+	return nil
+}
+
+// dirWatcher keeps track of the directories we're watching
+type dirWatcher struct {
+	watcher *fsnotify.Watcher
+	// dirs are the package directories we're updating. We also watch the directories above them, so we notice changes
+	// to the puku.json files that apply to them, and the go.mod, but we don't update the packages in those directories.
+	dirs map[string]struct{}
+	mux  sync.Mutex
+}
+
+// isPackage returns whether the directory is one of the package directories we're updating
+func (w *dirWatcher) isPackage(dir string) bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	_, ok := w.dirs[dir]
+	return ok
 }
 
-func add(watcher *fsnotify.Watcher, paths ...string) error {
+// packagesUnder returns the package directories that are in, or under, the directory
+func (w *dirWatcher) packagesUnder(dir string) []string {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	var ret []string
+	for path := range w.dirs {
+		if dir == "." || path == dir || strings.HasPrefix(path, dir+"/") {
+			ret = append(ret, path)
+		}
+	}
+	return ret
+}
+
+// addPackages starts watching a new directory, and the directories under it, returning the package directories that
+// were added. Directories are skipped in the same way as when expanding wildcards.
+func (w *dirWatcher) addPackages(root string) ([]string, error) {
+	var added []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// The directory may have been removed again before we got to it
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if skip, err := work.SkipDir(path); err != nil {
+			return err
+		} else if skip {
+			return filepath.SkipDir
+		}
+		added = append(added, path)
+		return nil
+	})
+
+	w.mux.Lock()
+	for _, path := range added {
+		w.dirs[path] = struct{}{}
+	}
+	w.mux.Unlock()
+
+	if addErr := w.add(added...); addErr != nil {
+		return added, addErr
+	}
+	return added, err
+}
+
+// removePackages stops watching a directory that has been removed, and the directories under it, returning the
+// package directories that were removed
+func (w *dirWatcher) removePackages(dir string) []string {
+	removed := w.packagesUnder(dir)
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	for _, path := range removed {
+		delete(w.dirs, path)
+		// The watch is usually removed along with the directory, in which case this returns an error we don't care about
+		_ = w.watcher.Remove(path)
+	}
+	return removed
+}
+
+// add starts watching the paths. If we hit the limit on the number of directories the OS lets us watch, we carry on
+// watching the ones we've managed to add rather than failing altogether.
+func (w *dirWatcher) add(paths ...string) error {
+	skipped := 0
 	for _, path := range paths {
 		if path == "" {
 			path = "."
 		}
-		err := watcher.Add(path)
-		if err != nil {
+		if err := w.watcher.Add(path); err != nil {
+			if errors.Is(err, syscall.ENOSPC) {
+				skipped++
+				continue
+			}
 			return err
 		}
 	}
+	if skipped > 0 {
+		log.Warningf("Reached the limit on the number of directories that can be watched, so changes in %d directories won't be picked up. "+
+			"On Linux, this limit can be raised with the fs.inotify.max_user_watches sysctl.", skipped)
+	}
 	return nil
 }
+
+// parentDirs returns the directories above the package directories, up to the repo root, that aren't package
+// directories themselves
+func parentDirs(dirs map[string]struct{}) []string {
+	parents := map[string]struct{}{}
+	for dir := range dirs {
+		for dir != "." {
+			dir = filepath.Dir(dir)
+			if _, ok := dirs[dir]; ok {
+				continue
+			}
+			parents[dir] = struct{}{}
+		}
+	}
+
+	ret := make([]string, 0, len(parents))
+	for dir := range parents {
+		ret = append(ret, dir)
+	}
+	return ret
+}
+
+func isBuildFile(config *please.Config, name string) bool {
+	for _, buildFileName := range config.BuildFileNames() {
+		if name == buildFileName {
+			return true
+		}
+	}
+	return false
+}
@@ -83,18 +83,9 @@ func ExpandPaths(originalWorkingDir string, paths []string) ([]string, error) {
 			if !d.IsDir() {
 				return nil
 			}
-			if d.Name() == "plz-out" {
-				return filepath.SkipDir
-			}
-			if d.Name() == ".git" {
-				return filepath.SkipDir
-			}
-			conf, err := config.ReadConfig(path)
-			if err != nil {
+			if skip, err := SkipDir(path); err != nil {
 				return err
-			}
-
-			if conf.GetStop() {
+			} else if skip {
 				return filepath.SkipDir
 			}
 			ret = append(ret, path)
@@ -108,6 +99,19 @@ func ExpandPaths(originalWorkingDir string, paths []string) ([]string, error) {
 	return ret, nil
 }
 
+// SkipDir returns whether puku should skip a directory, and everything under it, when expanding wildcards or watching
+// for changes. This skips build output, the git directory, and any directories with a config that sets stop.
+func SkipDir(path string) (bool, error) {
+	if name := filepath.Base(path); name == "plz-out" || name == ".git" {
+		return true, nil
+	}
+	conf, err := config.ReadConfig(path)
+	if err != nil {
+		return false, err
+	}
+	return conf.GetStop(), nil
+}
+
 // FindRoot finds the root of the workspace
 func FindRoot() (string, error) {
 	dir, err := os.Getwd()
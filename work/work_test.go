@@ -35,3 +35,24 @@ func TestExpandPaths(t *testing.T) {
 	require.NoError(t, err)
 	assert.ElementsMatch(t, ret, []string{"bar"})
 }
+
+func TestSkipDir(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	require.NoError(t, os.MkdirAll("foo/stopped", os.ModePerm))
+	require.NoError(t, os.WriteFile("foo/stopped/puku.json", []byte(`{"stop": true}`), 0644))
+
+	for path, expected := range map[string]bool{
+		"foo":         false,
+		"foo/stopped": true,
+		"plz-out":     true,
+		"foo/.git":    true,
+	} {
+		skip, err := SkipDir(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, skip, path)
+	}
+}